@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// amdahlFit — параметри закону Амдала, підібрані за виміряним прискоренням:
+// S(n) = 1 / ((1 - p) + p/n).
+type amdahlFit struct {
+	P          float64 // Частка роботи, що виконується паралельно
+	MaxSpeedup float64 // Теоретична межа прискорення 1/(1-p); 0 — необмежене
+	R2         float64 // Коефіцієнт детермінації для прискорення
+	RMSE       float64 // Середньоквадратична похибка прискорення
+	Configs    int     // Кількість конфігурацій, за якими підібрано p
+}
+
+// fitAmdahl підбирає p методом найменших квадратів за паралельними рядками.
+// З T(n)/T(1) = 1 - p(1 - 1/n) маємо y = p·x для x = 1 - 1/n та y = 1 - 1/S(n),
+// тож p = Σxy / Σx². Рядки з одним потоком не несуть інформації про p і пропускаються.
+// Повертає помилку, якщо різних кількостей потоків більше 1 менше двох.
+func fitAmdahl(rows []benchmarkRow) (amdahlFit, error) {
+	var fitted []benchmarkRow
+	threads := make(map[int]bool)
+	for _, row := range rows {
+		if row.Sequential || row.Threads < 2 || row.Speedup <= 0 {
+			continue
+		}
+		fitted = append(fitted, row)
+		threads[row.Threads] = true
+	}
+	if len(threads) < 2 {
+		return amdahlFit{}, errors.New("для апроксимації законом Амдала потрібні щонайменше дві різні кількості потоків більше 1")
+	}
+
+	var sumXY, sumXX float64
+	for _, row := range fitted {
+		x := 1 - 1/float64(row.Threads)
+		y := 1 - 1/row.Speedup
+		sumXY += x * y
+		sumXX += x * x
+	}
+	p := sumXY / sumXX
+
+	var meanS float64
+	for _, row := range fitted {
+		meanS += row.Speedup
+	}
+	meanS /= float64(len(fitted))
+
+	var ssRes, ssTot float64
+	for _, row := range fitted {
+		predicted := 1 / ((1 - p) + p/float64(row.Threads))
+		ssRes += (row.Speedup - predicted) * (row.Speedup - predicted)
+		ssTot += (row.Speedup - meanS) * (row.Speedup - meanS)
+	}
+	r2 := 1.0
+	if ssTot > 0 {
+		r2 = 1 - ssRes/ssTot
+	}
+
+	fit := amdahlFit{
+		P:       p,
+		R2:      r2,
+		RMSE:    math.Sqrt(ssRes / float64(len(fitted))),
+		Configs: len(fitted),
+	}
+	if p < 1 {
+		fit.MaxSpeedup = 1 / (1 - p)
+	}
+	return fit, nil
+}
+
+// amdahlReport форматує результат апроксимації для markdown-звіту.
+func amdahlReport(fit amdahlFit) string {
+	var sb strings.Builder
+	sb.WriteString("**Апроксимація законом Амдала:**\n\n")
+	fmt.Fprintf(&sb, "- Паралельна частка p: %.4f\n", fit.P)
+	if fit.MaxSpeedup > 0 {
+		fmt.Fprintf(&sb, "- Теоретичне максимальне прискорення: %.2f\n", fit.MaxSpeedup)
+	} else {
+		sb.WriteString("- Теоретичне максимальне прискорення: необмежене\n")
+	}
+	fmt.Fprintf(&sb, "- Якість апроксимації: R² = %.4f, RMSE = %.4f (за %d конфігураціями)\n", fit.R2, fit.RMSE, fit.Configs)
+	if fit.P < 0 {
+		sb.WriteString("\np < 0: паралельні запуски повільніші за послідовний, а закон Амдала не враховує накладних витрат паралелізму.\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"runtime"
+	"time"
+
+	"github.com/njnjfnj/DSPCT-Lab2-Ilhin-KI-32/montecarlo"
+)
+
+// printCommands виводить перелік підкоманд.
+func printCommands(w io.Writer) {
+	fmt.Fprint(w, `Використання: pi <підкоманда> [прапорці]
+
+Підкоманди:
+  sweep      виміряти час обчислення для кожної кількості потоків (за замовчуванням)
+  estimate   обчислити PI один раз (або протягом -duration) і вивести результат
+  converge   показати збіжність PI при зростаючій кількості точок
+  serve      запустити HTTP-сервер з поточною оцінкою PI
+  verify     перевірити, що паралельне обчислення з одним потоком збігається з послідовним
+  selfbench  виконати фіксований тест продуктивності і вивести підсумок одним рядком
+  cost       оцінити кількість точок для заданої похибки, не запускаючи обчислень
+
+Прапорці підкоманди: pi <підкоманда> -h
+`)
+}
+
+// isHelpFlag повідомляє, чи є arg прапорцем довідки, який розпізнає пакет flag.
+func isHelpFlag(arg string) bool {
+	switch arg {
+	case "-h", "-help", "--h", "--help":
+		return true
+	}
+	return false
+}
+
+// legacyModeFlags зіставляє прапорці sweep, що запускають окремий режим замість
+// перебору, з підкомандами, які їх замінили. Прапорці залишаються для сумісності.
+var legacyModeFlags = map[string]string{
+	"serve":                    "pi serve -addr",
+	"points-per-second-target": "pi serve -points-per-second-target",
+	"convergence":              "pi converge",
+	"duration":                 "pi estimate -duration",
+	"verify":                   "pi verify",
+	"selfbench":                "pi selfbench",
+	"estimate-cost":            "pi cost -error",
+}
+
+// deprecatedUsage позначає опис usage застарілого прапорця name з legacyModeFlags.
+func deprecatedUsage(name, usage string) string {
+	return fmt.Sprintf("застаріло, використовуйте %q: %s", legacyModeFlags[name], usage)
+}
+
+// warnLegacyFlags попереджає про кожен заданий у fs застарілий прапорець режиму.
+func warnLegacyFlags(fs *flag.FlagSet, logger *slog.Logger) {
+	fs.Visit(func(f *flag.Flag) {
+		if use, ok := legacyModeFlags[f.Name]; ok {
+			logger.Warn("застарілий прапорець", "flag", "-"+f.Name, "use", use)
+		}
+	})
+}
+
+// newCommandFlags створює набір прапорців підкоманди name зі спільними
+// прапорцями -seed та -quiet.
+func newCommandFlags(name string) (fs *flag.FlagSet, seed *int64, quiet *bool) {
+	fs = flag.NewFlagSet(name, flag.ExitOnError)
+	seed = fs.Int64("seed", 0, "базове зерно генератора (має пріоритет над змінною середовища "+seedEnvVar+")")
+	quiet = fs.Bool("quiet", false, "не виводити інформаційні повідомлення, лише попередження та результат")
+	return fs, seed, quiet
+}
+
+// runEstimate виконує підкоманду estimate: одне паралельне обчислення PI.
+func runEstimate(args []string) {
+	fs, seedFlag, quiet := newCommandFlags("estimate")
+	points := fs.Int("points", defaultTotalPoints, "загальна кількість точок")
+	threads := fs.Int("threads", runtime.NumCPU(), "кількість потоків")
+	duration := fs.Duration("duration", 0, "генерувати точки протягом заданого часу, наприклад 5s, замість -points")
+	fs.Parse(args)
+
+	logger := newLogger(*quiet)
+	seedOpts, seed, seedSet := seedOptions(fs, *seedFlag, logger)
+	if *duration != 0 {
+		printDuration(*duration, *threads, seedOpts, logger)
+		return
+	}
+
+	cfg := montecarlo.Config{Points: *points, Threads: *threads}
+	if seedSet {
+		cfg.Seed, cfg.SeedSet = seed, true
+	}
+	est, err := montecarlo.NewEstimator(cfg)
+	if err != nil {
+		printErrors(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	res, _ := montecarlo.Timed(func() (montecarlo.PiResult, error) { return est.Run(), nil })
+	printResult(res)
+}
+
+// runConverge виконує підкоманду converge.
+func runConverge(args []string) {
+	fs, seedFlag, quiet := newCommandFlags("converge")
+	threads := fs.Int("threads", runtime.NumCPU(), "кількість потоків")
+	fs.Parse(args)
+
+	logger := newLogger(*quiet)
+	seedOpts, _, _ := seedOptions(fs, *seedFlag, logger)
+	printConvergence(*threads, seedOpts)
+}
+
+// runServe виконує підкоманду serve.
+func runServe(args []string) {
+	fs, seedFlag, quiet := newCommandFlags("serve")
+	addr := fs.String("addr", ":8080", "адреса HTTP-сервера")
+	threads := fs.Int("threads", runtime.NumCPU(), "кількість потоків кожної порції /add")
+	rateTarget := fs.Float64("points-per-second-target", 0, "обмежити швидкість кожного воркера заданою кількістю точок за секунду (для демонстрацій)")
+	fs.Parse(args)
+
+	if *threads < 1 {
+		fmt.Fprintf(os.Stderr, "Помилка: кількість потоків має бути додатною, отримано %d\n", *threads)
+		os.Exit(2)
+	}
+	if *rateTarget < 0 {
+		fmt.Fprintf(os.Stderr, "Помилка: цільова швидкість має бути додатною, отримано %g\n", *rateTarget)
+		os.Exit(2)
+	}
+
+	logger := newLogger(*quiet)
+	seedOpts, _, _ := seedOptions(fs, *seedFlag, logger)
+	serveAccumulator(*addr, *threads, *rateTarget, seedOpts, logger)
+}
+
+// runVerifyCommand виконує підкоманду verify.
+func runVerifyCommand(args []string) {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	points := fs.Int("points", defaultTotalPoints, "кількість точок")
+	fs.Parse(args)
+	printVerify(*points)
+}
+
+// runSelfbenchCommand виконує підкоманду selfbench. Параметри тесту фіксовані,
+// тож прапорців, крім -h, немає.
+func runSelfbenchCommand(args []string) {
+	fs := flag.NewFlagSet("selfbench", flag.ExitOnError)
+	fs.Parse(args)
+	printSelfbench()
+}
+
+// runCostCommand виконує підкоманду cost.
+func runCostCommand(args []string) {
+	fs := flag.NewFlagSet("cost", flag.ExitOnError)
+	target := fs.Float64("error", 0.001, "цільова стандартна похибка оцінки PI")
+	fs.Parse(args)
+	printPointsForAccuracy(*target)
+}
+
+// printDuration обчислює PI в numThreads горутинах протягом d і виводить результат.
+func printDuration(d time.Duration, numThreads int, seedOpts []montecarlo.Option, logger *slog.Logger) {
+	res, err := montecarlo.EstimatePiForDuration(d, numThreads, append(seedOpts, montecarlo.WithLogger(logger))...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+		os.Exit(1)
+	}
+	printResult(res)
+}
+
+// printPointsForAccuracy виводить кількість точок, потрібну для стандартної похибки target.
+func printPointsForAccuracy(target float64) {
+	points, err := montecarlo.PointsForAccuracy(target)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+		os.Exit(2)
+	}
+	fmt.Printf("Для стандартної похибки %g потрібно приблизно %d точок\n", target, points)
+}
+
+// printConvergence обчислює та виводить таблицю збіжності.
+func printConvergence(numThreads int, seedOpts []montecarlo.Option) {
+	rows, err := runConvergence(numThreads, seedOpts...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(convergenceReport(rows))
+}
+
+// serveAccumulator запускає HTTP-сервер над Accumulator з numThreads потоками,
+// доки не надійде Ctrl-C, і записує в лог підсумкову оцінку.
+func serveAccumulator(addr string, numThreads int, rateTarget float64, seedOpts []montecarlo.Option, logger *slog.Logger) {
+	opts := append(seedOpts, montecarlo.WithLogger(logger), montecarlo.WithThrottle(rateTarget))
+	acc := montecarlo.NewAccumulator(numThreads, opts...)
+	logger.Info("HTTP-сервер запущено", "addr", addr, "endpoints", "/pi, /add?points=N, /metrics")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := serve(ctx, newServer(addr, acc), acc); err != nil {
+		fmt.Fprintf(os.Stderr, "Помилка сервера: %v\n", err)
+		os.Exit(1)
+	}
+	res := acc.Result()
+	logger.Info("HTTP-сервер зупинено", "pi", res.Pi, "total", res.Total)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/njnjfnj/DSPCT-Lab2-Ilhin-KI-32/montecarlo"
+)
+
+// Межі та крок геометричної прогресії кількості точок для режиму збіжності.
+const (
+	convergenceMinPoints = 1000
+	convergenceMaxPoints = 100000000
+	convergenceFactor    = 10
+)
+
+// convergenceRow описує оцінку PI для однієї кількості точок.
+type convergenceRow struct {
+	Points   int
+	Pi       float64
+	AbsError float64 // |PI - math.Pi|
+}
+
+// runConvergence обчислює PI паралельно при зростаючій кількості точок.
+func runConvergence(numThreads int, opts ...montecarlo.Option) ([]convergenceRow, error) {
+	var rows []convergenceRow
+	for points := convergenceMinPoints; points <= convergenceMaxPoints; points *= convergenceFactor {
+		res, err := montecarlo.EstimatePi(points, numThreads, opts...)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, convergenceRow{
+			Points:   points,
+			Pi:       res.Pi,
+			AbsError: math.Abs(res.Pi - math.Pi),
+		})
+	}
+	return rows, nil
+}
+
+// convergenceReport формує markdown-таблицю збіжності.
+func convergenceReport(rows []convergenceRow) string {
+	var sb strings.Builder
+	sb.WriteString("**Збіжність оцінки PI зі зростанням кількості точок:**\n\n")
+	sb.WriteString("| Кількість точок | Отримане PI | Абсолютна похибка |\n")
+	for _, row := range rows {
+		fmt.Fprintf(&sb, "| %-15d | %.6f | %.6f |\n", row.Points, row.Pi, row.AbsError)
+	}
+	return sb.String()
+}
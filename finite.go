@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/njnjfnj/DSPCT-Lab2-Ilhin-KI-32/montecarlo"
+)
+
+// nonFiniteMsg описує оцінку, що не є скінченним числом, у повідомленнях про помилки.
+const nonFiniteMsg = "нескінченна або невизначена оцінка"
+
+// nonFiniteText позначає таку оцінку в клітинках звітів.
+const nonFiniteText = "ПОМИЛКА: " + nonFiniteMsg
+
+// isFinite повідомляє, чи є v скінченним числом, а не NaN чи ±Inf.
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// formatEstimate форматує оцінку PI зі стандартною похибкою, явно позначаючи
+// нескінченні та невизначені значення, щоб вони не виглядали звичайним числом.
+func formatEstimate(pi, stdErr float64) string {
+	if !isFinite(pi) || !isFinite(stdErr) {
+		return fmt.Sprintf("%s (%v ± %v)", nonFiniteText, pi, stdErr)
+	}
+	return fmt.Sprintf("%.6f ± %.4f", pi, stdErr)
+}
+
+// checkFinite повертає об'єднану помилку з кожним рядком, оцінка PI чи
+// стандартна похибка якого не є скінченним числом, або nil, якщо таких немає.
+func checkFinite(rows []benchmarkRow) error {
+	var errs []error
+	for _, row := range rows {
+		if err := checkFiniteRow(row); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkFiniteRow перевіряє оцінку одного рядка.
+func checkFiniteRow(row benchmarkRow) error {
+	if isFinite(row.Pi) && isFinite(row.StdErr) {
+		return nil
+	}
+	threads := fmt.Sprint(row.Threads)
+	if row.Sequential {
+		threads += " (послідовно)"
+	}
+	return fmt.Errorf("%s при %s потоках: PI %v ± %v", nonFiniteMsg, threads, row.Pi, row.StdErr)
+}
+
+// printResult виводить res одним рядком і завершує процес з кодом 1,
+// якщо оцінка не є скінченним числом.
+func printResult(res montecarlo.PiResult) {
+	fmt.Println(res)
+	if !isFinite(res.Pi) || !isFinite(res.StdErr) {
+		fmt.Fprintf(os.Stderr, "Помилка: %s\n", nonFiniteMsg)
+		os.Exit(1)
+	}
+}
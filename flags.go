@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// intList — значення прапорця у вигляді списку додатних цілих чисел через кому.
+type intList []int
+
+// String повертає список у форматі "1,2,4".
+func (l *intList) String() string {
+	parts := make([]string, len(*l))
+	for i, v := range *l {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Set розбирає список через кому, відхиляючи нечислові та недодатні значення.
+func (l *intList) Set(s string) error {
+	var values []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return fmt.Errorf("%q не є цілим числом", part)
+		}
+		if v < 1 {
+			return fmt.Errorf("значення має бути додатним, отримано %d", v)
+		}
+		values = append(values, v)
+	}
+	*l = values
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// gnuplotScriptPath повертає шлях супровідного скрипту gnuplot: шлях даних із розширенням .gp.
+func gnuplotScriptPath(dataPath string) string {
+	return strings.TrimSuffix(dataPath, filepath.Ext(dataPath)) + ".gp"
+}
+
+// gnuplotData формує файл даних з колонками, розділеними пробілами.
+// Рядок послідовного обчислення записується коментарем, щоб не збігатися
+// з паралельним запуском на одному потоці.
+func gnuplotData(rows []benchmarkRow) string {
+	var sb strings.Builder
+	sb.WriteString("# threads time_ms speedup\n")
+	for _, row := range rows {
+		if row.Sequential {
+			fmt.Fprintf(&sb, "# sequential: %.3f ms\n", row.ElapsedMs)
+			continue
+		}
+		fmt.Fprintf(&sb, "%d %.3f %.4f\n", row.Threads, row.ElapsedMs, row.Speedup)
+	}
+	return sb.String()
+}
+
+// gnuplotScript формує скрипт, що будує графіки часу та прискорення за файлом dataPath.
+func gnuplotScript(dataPath string) string {
+	data := filepath.Base(dataPath)
+	var sb strings.Builder
+	sb.WriteString("set logscale x 2\n")
+	sb.WriteString("set xlabel 'Кількість потоків'\n")
+	sb.WriteString("set ylabel 'Час обчислення (мс)'\n")
+	sb.WriteString("set y2label 'Прискорення'\n")
+	sb.WriteString("set y2tics\n")
+	sb.WriteString("set grid\n")
+	fmt.Fprintf(&sb, "plot '%s' using 1:2 with linespoints title 'Час (мс)', \\\n", data)
+	fmt.Fprintf(&sb, "     '%s' using 1:3 axes x1y2 with linespoints title 'Прискорення'\n", data)
+	return sb.String()
+}
+
+// writeGnuplot записує файл даних dataPath і супровідний скрипт поруч із ним.
+func writeGnuplot(dataPath string, rows []benchmarkRow) error {
+	if err := os.WriteFile(dataPath, []byte(gnuplotData(rows)), 0o644); err != nil {
+		return fmt.Errorf("не вдалося записати дані gnuplot: %w", err)
+	}
+	if err := os.WriteFile(gnuplotScriptPath(dataPath), []byte(gnuplotScript(dataPath)), 0o644); err != nil {
+		return fmt.Errorf("не вдалося записати скрипт gnuplot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/njnjfnj/DSPCT-Lab2-Ilhin-KI-32/montecarlo"
+)
+
+// defaultHistogramSize — розмір сітки гістограми за замовчуванням.
+const defaultHistogramSize = 50
+
+// runHistogram обчислює PI паралельно і повертає гістограму точок на сітці size×size.
+func runHistogram(totalPoints, numThreads, size int, opts ...montecarlo.Option) ([][]int, error) {
+	var cells [][]int
+	opts = append(opts, montecarlo.WithHistogram(size, func(c [][]int) { cells = c }))
+	if _, err := montecarlo.EstimatePi(totalPoints, numThreads, opts...); err != nil {
+		return nil, err
+	}
+	return cells, nil
+}
+
+// writeHistogram записує гістограму у файл path: як зображення PPM,
+// якщо розширення .ppm, інакше як CSV.
+func writeHistogram(path string, cells [][]int) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("не вдалося створити файл гістограми: %w", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	if strings.EqualFold(filepath.Ext(path), ".ppm") {
+		writePPM(w, cells)
+	} else if err := writeHistogramCSV(w, cells); err != nil {
+		return fmt.Errorf("не вдалося записати гістограму: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("не вдалося записати гістограму: %w", err)
+	}
+	return nil
+}
+
+// writeHistogramCSV записує гістограму рядками сітки, починаючи з найменших y.
+func writeHistogramCSV(w *bufio.Writer, cells [][]int) error {
+	cw := csv.NewWriter(w)
+	for _, row := range cells {
+		record := make([]string, len(row))
+		for i, n := range row {
+			record[i] = strconv.Itoa(n)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writePPM записує гістограму як текстове зображення PPM (P3).
+// Клітинки чверті кола мають синій відтінок, решта — червоний, а яскравість
+// пропорційна кількості точок. Рядки виводяться згори вниз, тож вісь y спрямована вгору.
+func writePPM(w *bufio.Writer, cells [][]int) {
+	size := len(cells)
+	maxCount := 1
+	for _, row := range cells {
+		for _, n := range row {
+			maxCount = max(maxCount, n)
+		}
+	}
+
+	fmt.Fprintf(w, "P3\n%d %d\n255\n", size, size)
+	for y := size - 1; y >= 0; y-- {
+		for x, n := range cells[y] {
+			v := 255 * n / maxCount
+			// Центр клітинки визначає, чи вона належить чверті кола
+			cx, cy := (float64(x)+0.5)/float64(size), (float64(y)+0.5)/float64(size)
+			if cx*cx+cy*cy <= 1 {
+				fmt.Fprintf(w, "%d %d %d ", v/3, v/3, v)
+			} else {
+				fmt.Fprintf(w, "%d %d %d ", v, v/3, v/3)
+			}
+		}
+		w.WriteByte('\n')
+	}
+}
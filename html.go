@@ -0,0 +1,64 @@
+package main
+
+import (
+	"html/template"
+	"io"
+)
+
+// htmlReportTemplate — HTML-сторінка звіту з таблицею результатів.
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"ratio":    formatRatio,
+	"elapsed":  benchmarkRow.formatElapsed,
+	"bytes":    formatBytes,
+	"estimate": formatEstimate,
+}).Parse(`<!DOCTYPE html>
+<html lang="uk">
+<head>
+<meta charset="utf-8">
+<title>Звіт про залежність часу обчислення від кількості потоків</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+caption { caption-side: bottom; padding-top: 0.5em; color: #555; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.8em; text-align: right; }
+th { background: #f0f0f0; }
+tr.sequential { font-style: italic; }
+</style>
+</head>
+<body>
+<h1>Звіт про залежність часу обчислення від кількості потоків</h1>
+<table>
+<caption>Система: {{.System}}</caption>
+<thead>
+<tr><th>Кількість Потоків</th><th>Отримане PI</th><th>Абсолютна похибка</th><th>Відносна похибка (%)</th><th>Час Обчислення (мс)</th><th>Прискорення</th><th>Ефективність</th><th>Точок/с</th>{{if $.WithMem}}<th>Виділено пам'яті</th><th>Збирань сміття</th>{{end}}</tr>
+</thead>
+<tbody>
+{{- range .Results}}
+<tr{{if .Sequential}} class="sequential"{{end}}><td>{{.Threads}}{{if .Sequential}} (Послідовно){{end}}</td><td>{{estimate .Pi .StdErr}}</td><td>{{printf "%.6f" .AbsError}}</td><td>{{printf "%.4f" .RelError}}</td><td>{{elapsed .}}</td><td>{{ratio .Speedup}}</td><td>{{ratio .Efficiency}}</td><td>{{printf "%.3g" .Throughput}}</td>{{with .Mem}}<td>{{bytes .AllocBytes}}</td><td>{{.NumGC}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+{{.Chart}}
+</body>
+</html>
+`))
+
+// htmlReport — дані HTML-сторінки: відомості про систему, результати
+// та вбудована SVG-діаграма прискорення.
+type htmlReport struct {
+	System  systemInfo
+	Results []benchmarkRow
+	Chart   template.HTML // Згенеровано speedupSVG, тож екранування не потрібне
+	WithMem bool          // Чи містять рядки статистику пам'яті
+}
+
+// writeHTMLReport записує звіт як HTML-сторінку з таблицею результатів,
+// відомостями про систему та діаграмою прискорення.
+func writeHTMLReport(w io.Writer, info systemInfo, rows []benchmarkRow) error {
+	return htmlReportTemplate.Execute(w, htmlReport{
+		System:  info,
+		Results: rows,
+		Chart:   template.HTML(speedupSVG(rows)),
+		WithMem: len(rows) > 0 && rows[0].Mem != nil,
+	})
+}
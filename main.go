@@ -1,134 +1,511 @@
 package main
 
 import (
+	"context"
+	"encoding/csv"
+	"flag"
 	"fmt"
-	"math/rand"
+	"log/slog"
+	"os"
+	"os/signal"
 	"runtime"
-	"sync"
-	"time"
+	"strings"
+
+	"github.com/njnjfnj/DSPCT-Lab2-Ilhin-KI-32/montecarlo"
 )
 
-const TotalPoints = 1000000
+// defaultTotalPoints — кількість точок за замовчуванням.
+const defaultTotalPoints = 1000000
+
+func main() {
+	args := os.Args[1:]
+	if len(args) > 0 && isHelpFlag(args[0]) {
+		printCommands(os.Stdout)
+		return
+	}
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		// Без підкоманди працює перебір, як до появи підкоманд
+		runSweep(args)
+		return
+	}
 
-// sequentialPi обчислює PI послідовно в одному потоці.
-func sequentialPi(numPoints int) float64 {
-	rand.Seed(time.Now().UnixNano())
-	insideCircle := 0
+	switch args[0] {
+	case "sweep":
+		runSweep(args[1:])
+	case "estimate":
+		runEstimate(args[1:])
+	case "converge":
+		runConverge(args[1:])
+	case "serve":
+		runServe(args[1:])
+	case "verify":
+		runVerifyCommand(args[1:])
+	case "selfbench":
+		runSelfbenchCommand(args[1:])
+	case "cost":
+		runCostCommand(args[1:])
+	case "help":
+		printCommands(os.Stdout)
+	default:
+		fmt.Fprintf(os.Stderr, "Помилка: невідома підкоманда %q\n\n", args[0])
+		printCommands(os.Stderr)
+		os.Exit(2)
+	}
+}
 
-	for i := 0; i < numPoints; i++ {
-		x := rand.Float64()
-		y := rand.Float64()
+// runSweep виконує підкоманду sweep: вимірює час обчислення для кожної
+// кількості потоків і виводить звіт. Прапорці режимів, що з'явилися до
+// підкоманд (див. legacyModeFlags), залишаються застарілими псевдонімами
+// відповідних підкоманд, тож виклик без підкоманди працює як раніше.
+func runSweep(args []string) {
+	fs := flag.NewFlagSet("sweep", flag.ExitOnError)
+	format := fs.String("format", "markdown", "формат звіту: markdown, json, html або ndjson (рядок JSON на кожну конфігурацію одразу після її завершення)")
+	outPath := fs.String("o", "", "записати markdown-звіт у файл замість виводу на екран")
+	csvPath := fs.String("csv", "", "дописати результати у CSV-файл за вказаним шляхом")
+	totalPoints := fs.Int("points", defaultTotalPoints, "загальна кількість точок")
+	threadCounts := intList{2, 4, 8, 16, 32, 64}
+	fs.Var(&threadCounts, "threads", "список кількостей потоків через кому")
+	repeat := fs.Int("repeat", 1, "кількість повторів кожної конфігурації")
+	auto := fs.Bool("auto", false, "автоматично підібрати кількість потоків замість перебору -threads")
+	quiet := fs.Bool("quiet", false, "не виводити інформаційні повідомлення, лише попередження та звіт")
+	cpuProfile := fs.String("cpuprofile", "", "записати CPU-профіль у файл")
+	memProfile := fs.String("memprofile", "", "записати профіль пам'яті у файл")
+	serveAddr := fs.String("serve", "", deprecatedUsage("serve", "запустити HTTP-сервер з поточною оцінкою PI за адресою, наприклад :8080"))
+	convergence := fs.Bool("convergence", false, deprecatedUsage("convergence", "показати збіжність PI при зростаючій кількості точок"))
+	compareRNG := fs.Bool("compare-rng", false, "порівняти швидкість і точність різних генераторів випадкових чисел")
+	verify := fs.Bool("verify", false, deprecatedUsage("verify", "перевірити, що паралельне обчислення з одним потоком збігається з послідовним"))
+	workerTimes := fs.Bool("worker-times", false, "записувати в лог мінімальний, максимальний і середній час воркерів")
+	seedFlag := fs.Int64("seed", 0, "базове зерно генератора (має пріоритет над змінною середовища "+seedEnvVar+")")
+	plan := fs.Bool("plan", false, "показати розподіл точок між воркерами без запуску обчислень")
+	svgPath := fs.String("svg", "", "записати стовпчикову діаграму прискорення за кількістю потоків у SVG-файл")
+	gnuplotPath := fs.String("gnuplot", "", "записати дані для gnuplot у файл і скрипт .gp поруч із ним")
+	histogramPath := fs.String("histogram", "", "записати гістограму точок у файл: .ppm — зображення, інакше CSV")
+	histogramSize := fs.Int("histogram-size", defaultHistogramSize, "розмір сітки гістограми")
+	configPath := fs.String("config", "", "прочитати налаштування експерименту з JSON-файлу; прапорці мають пріоритет")
+	warmup := fs.Bool("warmup", false, "перед вимірюваннями кожної конфігурації виконати один прогрів, результат якого відкидається")
+	estimateCost := fs.Float64("estimate-cost", 0, deprecatedUsage("estimate-cost", "оцінити кількість точок для заданої абсолютної похибки, не запускаючи обчислень"))
+	compareSeeds := fs.Int("compare-seeds", 0, "показати розкид оцінок PI для заданої кількості різних фіксованих зерен")
+	selfbench := fs.Bool("selfbench", false, deprecatedUsage("selfbench", "виконати фіксований тест продуктивності і вивести підсумок одним рядком"))
+	memstats := fs.Bool("memstats", false, "додати до звіту виділену пам'ять і кількість збирань сміття кожної конфігурації (трохи спотворює час)")
+	dynamic := fs.Bool("dynamic", false, "паралельні запуски розподіляють точки динамічно порціями зі спільного лічильника")
+	rateTarget := fs.Float64("points-per-second-target", 0, deprecatedUsage("points-per-second-target", "обмежити швидкість кожного воркера режиму -serve заданою кількістю точок за секунду (для демонстрацій)"))
+	quasi := fs.Bool("quasi", false, "паралельні запуски беруть точки з квазівипадкової послідовності Гальтона")
+	profileScaling := fs.Bool("profile-scaling", false, "апроксимувати виміряне прискорення законом Амдала і вивести паралельну частку p")
+	duration := fs.Duration("duration", 0, deprecatedUsage("duration", "генерувати точки на всіх ядрах протягом заданого часу, наприклад 5s, і вивести досягнуту оцінку"))
+	precision := fs.Int("precision", 64, "розрядність координат паралельних запусків: 32 (float32) або 64 (float64)")
+	affinity := fs.Bool("affinity", false, "прив'язати воркерів паралельних запусків до логічних процесорів (лише Linux)")
+	fs.Parse(args)
 
-		// Перевіряємо, чи точка потрапила в коло з радіусом 1
-		if x*x+y*y <= 1.0 {
-			insideCircle++
+	if *configPath != "" {
+		cfg, err := loadConfig(*configPath)
+		if err == nil {
+			err = cfg.apply(fs)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(2)
 		}
 	}
 
-	// PI ≈ 4 * (Кількість точок в колі / Загальна кількість точок)
-	return 4.0 * float64(insideCircle) / float64(numPoints)
-}
+	runCfg := runConfig{
+		Points:        *totalPoints,
+		Repeat:        *repeat,
+		Format:        *format,
+		ServeAddr:     *serveAddr,
+		RateTarget:    *rateTarget,
+		HistogramPath: *histogramPath,
+		HistogramSize: *histogramSize,
+		CompareSeeds:  *compareSeeds,
+		EstimateCost:  *estimateCost,
+
+		Duration:       *duration,
+		Precision:      *precision,
+		ProfileScaling: *profileScaling,
+		Threads:        threadCounts,
+		Auto:           *auto,
+	}
+	if err := runCfg.Validate(); err != nil {
+		printErrors(os.Stderr, err)
+		os.Exit(2)
+	}
 
-// worker обчислює PI для заданої кількості точок і надсилає результат в канал.
-// Використовує окремий генератор rand для кожної горутини, щоб уникнути race condition.
-func worker(numPoints int, resultChan chan int) {
-	// Для кожної горутини використовується окремий rand.Source,
-	// щоб уникнути синхронізації при генерації випадкових чисел.
-	// Час тут використовується як простий спосіб отримати унікальне зерно.
-	source := rand.NewSource(time.Now().UnixNano() + int64(numPoints))
-	r := rand.New(source)
+	// Статусні повідомлення пишуться структурованим логом у stderr,
+	// тож stdout містить лише звіт і JSON залишається валідним.
+	logger := newLogger(*quiet)
+	warnLegacyFlags(fs, logger)
 
-	insideCircle := 0
-	for i := 0; i < numPoints; i++ {
-		x := r.Float64()
-		y := r.Float64()
+	seedOpts, seed, seedSet := seedOptions(fs, *seedFlag, logger)
 
-		if x*x+y*y <= 1.0 {
-			insideCircle++
+	// Завершальні дії виконуються у зворотному порядку при виході з функції,
+	// а також перед os.Exit при перериванні, яке пропускає defer
+	var cleanups []func()
+	flush := func() {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
 		}
+		cleanups = nil
 	}
+	defer flush()
 
-	// Відправка результату (кількість точок в колі) в канал
-	resultChan <- insideCircle
-}
+	if *cpuProfile != "" {
+		stop, err := startCPUProfile(*cpuProfile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(1)
+		}
+		cleanups = append(cleanups, stop)
+	}
+	if *memProfile != "" {
+		cleanups = append(cleanups, func() {
+			if err := writeMemProfile(*memProfile); err != nil {
+				fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			}
+		})
+	}
 
-// parallelPi обчислює PI, розбиваючи роботу на numThreads горутин.
-func parallelPi(totalPoints, numThreads int) (float64, time.Duration) {
-	startTime := time.Now()
+	if *serveAddr != "" {
+		serveAccumulator(*serveAddr, runtime.NumCPU(), *rateTarget, seedOpts, logger)
+		return
+	}
 
-	// Встановлення максимальної кількості використовуваних ядер
-	runtime.GOMAXPROCS(numThreads)
+	if *convergence {
+		printConvergence(runtime.NumCPU(), seedOpts)
+		return
+	}
 
-	// Розподіл точок між потоками
-	pointsPerWorker := totalPoints / numThreads
-	remainder := totalPoints % numThreads
+	if *selfbench {
+		printSelfbench()
+		return
+	}
 
-	resultChan := make(chan int, numThreads) // Канал для збору результатів
-	var wg sync.WaitGroup                    // WaitGroup для з'єднання горутин
+	if *duration > 0 {
+		printDuration(*duration, runtime.NumCPU(), seedOpts, logger)
+		return
+	}
 
-	// Запуск горутин
-	for i := 0; i < numThreads; i++ {
-		currentPoints := pointsPerWorker
-		if i < remainder {
-			currentPoints++ // Додаємо залишок першим потокам
+	if *estimateCost != 0 {
+		printPointsForAccuracy(*estimateCost)
+		return
+	}
+
+	if *plan {
+		rows, err := planWork(*totalPoints, threadCounts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(1)
 		}
+		fmt.Println(planReport(*totalPoints, rows))
+		return
+	}
 
-		wg.Add(1)
-		go func(pts int) {
-			defer wg.Done()
-			worker(pts, resultChan)
-		}(currentPoints)
+	if *verify {
+		printVerify(*totalPoints)
+		return
 	}
 
-	// Асинхронне закриття каналу після завершення всіх горутин (аналог join)
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
+	if *histogramPath != "" {
+		cells, err := runHistogram(*totalPoints, runtime.NumCPU(), *histogramSize, seedOpts...)
+		if err == nil {
+			err = writeHistogram(*histogramPath, cells)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(1)
+		}
+		logger.Info("гістограму записано", "path", *histogramPath, "size", *histogramSize)
+		return
+	}
 
-	// Збір результатів з каналу
-	totalInside := 0
-	for count := range resultChan {
-		totalInside += count
+	if *compareSeeds != 0 {
+		// Без явного зерна використовуються зерна 1, 2, ..., щоб звіт був відтворюваним
+		firstSeed := int64(1)
+		if seedSet {
+			firstSeed = seed
+		}
+		spread, err := runSeedSpread(*totalPoints, runtime.NumCPU(), *compareSeeds, firstSeed)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(seedSpreadReport(*totalPoints, spread))
+		return
 	}
 
-	elapsedTime := time.Since(startTime)
+	if *compareRNG {
+		candidates := defaultRNGCandidates(seed)
+		rows, err := runRNGComparison(*totalPoints, runtime.NumCPU(), candidates)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(rngReport(rows))
+		return
+	}
 
-	// Фінальне обчислення PI
-	pi := 4.0 * float64(totalInside) / float64(totalPoints)
-	return pi, elapsedTime
-}
+	var csvWriter *csv.Writer
+	if *csvPath != "" {
+		f, w, err := openCSV(*csvPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(1)
+		}
+		cleanups = append(cleanups, func() { f.Close() })
+		csvWriter = w
+	}
+
+	if *auto {
+		optimal := montecarlo.OptimalThreads(*totalPoints)
+		logger.Info("оптимальна кількість потоків", "threads", optimal)
+		threadCounts = intList{optimal}
+	}
 
-func main() {
 	runtime.GOMAXPROCS(runtime.NumCPU()) // Використовувати всі доступні ядра за замовчуванням
-	fmt.Println("Обчислення числа PI методом Монте-Карло")
-	fmt.Printf("Загальна кількість точок: %d\n\n", TotalPoints)
+	warnOversubscribed(logger, threadCounts, runtime.NumCPU())
+	logger.Info("обчислення числа PI методом Монте-Карло", "points", *totalPoints, "repeat", *repeat)
 
-	fmt.Println("--- Послідовне обчислення (один потік) ---")
-	startTimeSeq := time.Now()
-	piSeq := sequentialPi(TotalPoints)
-	elapsedTimeSeq := time.Since(startTimeSeq)
-	fmt.Printf("Отримане PI: %.6f\n", piSeq)
-	fmt.Printf("Час обчислення: %s\n", elapsedTimeSeq)
+	// Ctrl-C скасовує ctx: поточне обчислення зупиняється після своєї порції,
+	// і замість звіту виводиться оцінка за всіма вже згенерованими точками.
+	// Після першого сигналу stop знімає перехоплення, тож повторний Ctrl-C
+	// завершує процес одразу обробкою за замовчуванням.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+	var completed []montecarlo.PiResult // Усі завершені та перервані запуски
 
-	fmt.Println("\n--- Паралельне обчислення (різна кількість потоків) ---")
-	report := "**Звіт про залежність часу обчислення від кількості потоків:**\n\n"
-	report += "| Кількість Потоків | Отримане PI | Час Обчислення (мс) |\n"
+	// Базовий запуск виконується одним воркером без горутин, що дає ту саму
+	// послідовність точок, що й EstimatePiSequential, але перевіряє ctx після кожної порції
+	if *warmup {
+		montecarlo.EstimatePiContext(ctx, *totalPoints, 1, seedOpts...)
+		if ctx.Err() != nil {
+			exitInterrupted(completed, flush)
+		}
+	}
+	seqMem := startMemUsage(*memstats)
+	seqRuns := make([]montecarlo.PiResult, *repeat)
+	for i := range seqRuns {
+		seq, err := montecarlo.Timed(func() (montecarlo.PiResult, error) {
+			return montecarlo.EstimatePiContext(ctx, *totalPoints, 1, seedOpts...)
+		})
+		completed = append(completed, seq)
+		if ctx.Err() != nil {
+			exitInterrupted(completed, flush)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(1)
+		}
+		seqRuns[i] = seq
+	}
+	seqRow := newBenchmarkRow(1, *totalPoints, seqRuns)
+	seqRow.Sequential = true
+	seqRow.Mem = seqMem()
+	logRow(logger, "послідовне обчислення", seqRow)
+	emitRow := func(row benchmarkRow) {
+		if *format != "ndjson" {
+			return
+		}
+		// JSON не має подання NaN та Inf, тож такий рядок завершує потік помилкою
+		if err := checkFiniteRow(row); err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(1)
+		}
+		if err := writeNDJSONRow(os.Stdout, row); err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	emitRow(seqRow)
+	rows := []benchmarkRow{seqRow}
 
-	report += fmt.Sprintf("| 1 (Послідовно)   | %.6f   | %.2f |\n", piSeq, float64(elapsedTimeSeq.Microseconds())/1000.0)
+	for _, numThreads := range threadCounts {
+		// Ядро і планувальник задаються один раз, щоб прогрів і вимірювання
+		// виконували однаковий код
+		runOpts := append([]montecarlo.Option{}, seedOpts...)
+		if *dynamic {
+			runOpts = append(runOpts, montecarlo.WithDynamicScheduling(true))
+		}
+		if *quasi {
+			runOpts = append(runOpts, montecarlo.WithQuasiRandom(true))
+		}
+		if *precision == 32 {
+			runOpts = append(runOpts, montecarlo.WithFloat32(true))
+		}
+		if *affinity {
+			runOpts = append(runOpts, montecarlo.WithCPUAffinity(true))
+		}
 
-	threadCounts := []int{2, 4, 8, 16, 32, 64}
+		// Прогрів оплачує одноразові витрати (запуск горутин, виділення пам'яті),
+		// а його результат не входить ні у звіт, ні в оцінку при перериванні
+		if *warmup {
+			montecarlo.EstimatePiContext(ctx, *totalPoints, numThreads, runOpts...)
+			if ctx.Err() != nil {
+				exitInterrupted(completed, flush)
+			}
+		}
+		parMem := startMemUsage(*memstats)
+		parRuns := make([]montecarlo.PiResult, *repeat)
+		for i := range parRuns {
+			par, err := montecarlo.Timed(func() (montecarlo.PiResult, error) {
+				// Лог і час воркерів потрібні лише для вимірюваних запусків
+				opts := append([]montecarlo.Option{montecarlo.WithLogger(logger)}, runOpts...)
+				if *workerTimes {
+					opts = append(opts, montecarlo.WithWorkerTiming(func(t montecarlo.WorkerTiming) {
+						logWorkerTiming(logger, numThreads, t)
+					}))
+				}
+				return montecarlo.EstimatePiContext(ctx, *totalPoints, numThreads, opts...)
+			})
+			completed = append(completed, par)
+			if ctx.Err() != nil {
+				exitInterrupted(completed, flush)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+				os.Exit(1)
+			}
+			parRuns[i] = par
+		}
 
-	for _, numThreads := range threadCounts {
-		piPar, elapsedTimePar := parallelPi(TotalPoints, numThreads)
+		row := newBenchmarkRow(numThreads, *totalPoints, parRuns)
+		row.Mem = parMem()
+		logRow(logger, "паралельне обчислення", row)
+		emitRow(row)
+		rows = append(rows, row)
+	}
+
+	computeSpeedup(rows, seqRow.elapsed)
+	info := currentSystemInfo()
+
+	// Нескінченна оцінка позначається у звітах, а процес завершується з ненульовим кодом,
+	// щоб зламаний запуск не видавав себе за коректний; JSON таких значень не подає взагалі
+	finiteErr := checkFinite(rows)
+	if finiteErr != nil && *format == "json" {
+		printErrors(os.Stderr, finiteErr)
+		os.Exit(1)
+	}
+	report := markdownReport(info, rows)
+
+	if *profileScaling {
+		f, err := fitAmdahl(rows)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(1)
+		}
+		logger.Info("апроксимація законом Амдала", "p", f.P, "max_speedup", f.MaxSpeedup, "r2", f.R2, "rmse", f.RMSE)
+		report += "\n" + amdahlReport(f)
+	}
+
+	switch *format {
+	case "json":
+		if err := writeJSONReport(os.Stdout, info, rows); err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(1)
+		}
+	case "html":
+		if err := writeHTMLReport(os.Stdout, info, rows); err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(1)
+		}
+	case "ndjson":
+		// Рядки вже виведено по мірі завершення конфігурацій
+	default:
+		if *outPath == "" {
+			fmt.Println(report)
+		}
+	}
+
+	if *outPath != "" {
+		if err := os.WriteFile(*outPath, []byte(report), 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка запису звіту: %v\n", err)
+			os.Exit(1)
+		}
+		logger.Info("звіт записано", "path", *outPath)
+	}
+
+	if *svgPath != "" {
+		if err := writeSVG(*svgPath, rows); err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(1)
+		}
+		logger.Info("діаграму записано", "path", *svgPath)
+	}
+
+	if *gnuplotPath != "" {
+		if err := writeGnuplot(*gnuplotPath, rows); err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+			os.Exit(1)
+		}
+		logger.Info("дані gnuplot записано", "data", *gnuplotPath, "script", gnuplotScriptPath(*gnuplotPath))
+	}
+
+	if csvWriter != nil {
+		if err := writeCSVRows(csvWriter, rows); err != nil {
+			fmt.Fprintf(os.Stderr, "Помилка запису CSV: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if finiteErr != nil {
+		printErrors(os.Stderr, finiteErr)
+		os.Exit(1)
+	}
+}
 
-		fmt.Printf("Кількість потоків: %d\n", numThreads)
-		fmt.Printf("Отримане PI: %.6f\n", piPar)
-		fmt.Printf("Час обчислення: %s\n", elapsedTimePar)
+// exitInterrupted виводить оцінку PI, об'єднану за всіма вже згенерованими точками,
+// і завершує процес з кодом 130, як прийнято для переривання через SIGINT.
+// Перед виходом викликається flush, щоб дописати профілі та закрити файли.
+func exitInterrupted(results []montecarlo.PiResult, flush func()) {
+	res := montecarlo.Combine(results...)
+	if res.Total == 0 {
+		fmt.Println("Обчислення перервано до отримання перших точок")
+	} else {
+		fmt.Printf("Обчислення перервано. Оцінка за всіма запусками: %v\n", res)
+	}
+	flush()
+	os.Exit(130)
+}
 
-		report += fmt.Sprintf("| %d                  | %.6f   | %.2f |\n", numThreads, piPar, float64(elapsedTimePar.Microseconds())/1000.0)
+// newLogger створює логер статусних повідомлень у stderr.
+// У тихому режимі інформаційні повідомлення відкидаються, а попередження залишаються.
+func newLogger(quiet bool) *slog.Logger {
+	level := slog.LevelInfo
+	if quiet {
+		level = slog.LevelWarn
 	}
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+}
+
+// warnOversubscribed один раз попереджає, якщо найбільша кількість потоків
+// у переборі перевищує кількість процесорів: зайві потоки лише додають
+// накладні витрати планування, тому час на них може зростати. Запуск не переривається.
+func warnOversubscribed(logger *slog.Logger, threadCounts []int, numCPU int) {
+	maxThreads := 0
+	for _, n := range threadCounts {
+		maxThreads = max(maxThreads, n)
+	}
+	if maxThreads > numCPU {
+		logger.Warn(fmt.Sprintf("%d потоків на %d CPU — надлишкова підписка", maxThreads, numCPU),
+			"threads", maxThreads, "cpus", numCPU)
+	}
+}
+
+// logWorkerTiming записує в лог статистику часу воркерів одного запуску.
+func logWorkerTiming(logger *slog.Logger, numThreads int, t montecarlo.WorkerTiming) {
+	logger.Info("час воркерів",
+		"threads", numThreads,
+		"workers", t.Workers,
+		"min_ms", durationMs(t.Min),
+		"max_ms", durationMs(t.Max),
+		"mean_ms", durationMs(t.Mean),
+	)
+}
 
-	fmt.Println("\n--- Загальний результат ---")
-	fmt.Println(report)
+// logRow записує в лог проміжний результат однієї конфігурації.
+func logRow(logger *slog.Logger, msg string, row benchmarkRow) {
+	logger.Info(msg,
+		"threads", row.Threads,
+		"pi", row.Pi,
+		"stderr", row.StdErr,
+		"elapsed_ms", durationMs(row.elapsed),
+	)
 }
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// memUsage описує використання пам'яті за всі повтори однієї конфігурації.
+type memUsage struct {
+	AllocBytes uint64 `json:"alloc_bytes"` // Сумарно виділено байтів у купі
+	NumGC      uint32 `json:"num_gc"`      // Кількість завершених циклів збирання сміття
+}
+
+// startMemUsage знімає runtime.MemStats і повертає функцію, що знімає їх повторно
+// і повертає різницю. Якщо enabled хибне, статистика не читається, бо
+// runtime.ReadMemStats зупиняє світ і трохи спотворює час, а функція повертає nil.
+func startMemUsage(enabled bool) func() *memUsage {
+	if !enabled {
+		return func() *memUsage { return nil }
+	}
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+	return func() *memUsage {
+		var after runtime.MemStats
+		runtime.ReadMemStats(&after)
+		return &memUsage{
+			AllocBytes: after.TotalAlloc - before.TotalAlloc,
+			NumGC:      after.NumGC - before.NumGC,
+		}
+	}
+}
+
+// formatBytes форматує кількість байтів у двійкових одиницях.
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/njnjfnj/DSPCT-Lab2-Ilhin-KI-32/montecarlo"
+)
+
+// Описи метрик накопичувача.
+var (
+	piEstimateDesc = prometheus.NewDesc("montecarlo_pi_estimate",
+		"Current Monte Carlo estimate of Pi.", nil, nil)
+	piStdErrDesc = prometheus.NewDesc("montecarlo_pi_stderr",
+		"Standard error of the current estimate.", nil, nil)
+	pointsSampledDesc = prometheus.NewDesc("montecarlo_points_sampled_total",
+		"Total number of points sampled.", nil, nil)
+	pointsPerSecondDesc = prometheus.NewDesc("montecarlo_points_per_second",
+		"Average points sampled per second since the server started.", nil, nil)
+)
+
+// accumulatorCollector — prometheus.Collector, що при кожному зборі бере
+// один знімок Accumulator.Result, тож усі метрики узгоджені між собою.
+type accumulatorCollector struct {
+	acc     *montecarlo.Accumulator
+	started time.Time
+}
+
+// Describe надсилає описи всіх метрик колектора.
+func (c accumulatorCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- piEstimateDesc
+	ch <- piStdErrDesc
+	ch <- pointsSampledDesc
+	ch <- pointsPerSecondDesc
+}
+
+// Collect надсилає поточні значення метрик.
+func (c accumulatorCollector) Collect(ch chan<- prometheus.Metric) {
+	res := c.acc.Result()
+	pps := 0.0
+	if uptime := time.Since(c.started); uptime > 0 {
+		pps = float64(res.Total) / uptime.Seconds()
+	}
+	ch <- prometheus.MustNewConstMetric(piEstimateDesc, prometheus.GaugeValue, res.Pi)
+	ch <- prometheus.MustNewConstMetric(piStdErrDesc, prometheus.GaugeValue, res.StdErr)
+	ch <- prometheus.MustNewConstMetric(pointsSampledDesc, prometheus.CounterValue, float64(res.Total))
+	ch <- prometheus.MustNewConstMetric(pointsPerSecondDesc, prometheus.GaugeValue, pps)
+}
+
+// metricsHandler повертає обробник /metrics з метриками накопичувача acc
+// та стандартними метриками процесу і середовища Go. Метрики реєструються
+// в окремому реєстрі, а не в глобальному, тож кожен сервер має власний набір.
+// Швидкість генерації в Prometheus краще рахувати як rate(montecarlo_points_sampled_total[1m]),
+// а montecarlo_points_per_second — це середнє з моменту запуску сервера.
+func metricsHandler(acc *montecarlo.Accumulator) http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		accumulatorCollector{acc: acc, started: time.Now()},
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
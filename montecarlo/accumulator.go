@@ -0,0 +1,99 @@
+package montecarlo
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrStopped повертається Accumulator.Add після виклику Stop.
+var ErrStopped = errors.New("accumulator is stopped")
+
+// Accumulator поступово уточнює оцінку PI: кожен виклик Add генерує
+// нові точки і додає їх до накопичених лічильників. Усі методи
+// безпечні для одночасного виклику з кількох горутин.
+type Accumulator struct {
+	numThreads int
+	o          options
+
+	ctx    context.Context    // Скасовується в Stop
+	cancel context.CancelFunc // Перериває обчислення, що виконуються
+	wg     sync.WaitGroup     // Виклики Add, що виконуються
+
+	mu      sync.Mutex
+	sum     workerResult // Накопичені підсумки всіх порцій
+	batches int          // Кількість запущених порцій, для унікальних індексів воркерів
+	stopped bool         // Чи було викликано Stop
+}
+
+// NewAccumulator створює порожній Accumulator, що обчислює кожну порцію
+// точок у numThreads горутинах з налаштуваннями opts.
+// Після використання в довготривалих сценаріях Accumulator варто зупинити через Stop.
+func NewAccumulator(numThreads int, opts ...Option) *Accumulator {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Accumulator{numThreads: numThreads, o: newOptions(opts), ctx: ctx, cancel: cancel}
+}
+
+// Add генерує ще points точок і додає їх до накопичених лічильників.
+// Повертає помилку, якщо points або кількість потоків менша за 1.
+// Після Stop повертає ErrStopped; якщо Stop перервав порцію, що виконувалася,
+// вже згенеровані точки цієї порції все одно додаються.
+func (a *Accumulator) Add(points int) error {
+	// Кожна порція отримує власний діапазон індексів воркерів, тож при
+	// фіксованому зерні порції не повторюють одна одну
+	a.mu.Lock()
+	if a.stopped {
+		a.mu.Unlock()
+		return ErrStopped
+	}
+	a.wg.Add(1)
+	defer a.wg.Done()
+	o := a.o
+	o.firstWorker = a.batches * a.numThreads
+	a.batches++
+	a.mu.Unlock()
+
+	res, err := run(a.ctx, o.circleKernel(), points, a.numThreads, o)
+
+	a.mu.Lock()
+	a.sum.add(res)
+	a.mu.Unlock()
+
+	if err != nil && a.ctx.Err() != nil {
+		return ErrStopped
+	}
+	return err
+}
+
+// Stop перериває порції, що обчислюються, і чекає, доки всі їхні горутини
+// завершаться. Накопичені результати залишаються доступними через Result.
+// Повторні виклики лише чекають на завершення.
+func (a *Accumulator) Stop() {
+	a.mu.Lock()
+	a.stopped = true
+	a.mu.Unlock()
+
+	a.cancel()
+	a.wg.Wait()
+}
+
+// Estimate повертає поточну оцінку PI, або 0, якщо точок ще немає.
+func (a *Accumulator) Estimate() float64 {
+	return a.Result().Pi
+}
+
+// Total повертає кількість накопичених точок.
+func (a *Accumulator) Total() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.sum.sampled
+}
+
+// Result повертає поточну оцінку PI разом зі стандартною похибкою.
+func (a *Accumulator) Result() PiResult {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	res := a.sum.piResult(a.o.unitSize())
+	res.Threads = a.numThreads
+	return res
+}
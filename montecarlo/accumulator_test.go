@@ -0,0 +1,47 @@
+package montecarlo
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestAccumulatorStopNoLeak зупиняє Accumulator під час кількох порцій, що
+// ще обчислюються, і перевіряє, що Stop безпечно викликати повторно, Add після
+// нього повертає ErrStopped, а кількість горутин повертається до початкової.
+func TestAccumulatorStopNoLeak(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	// Обмеження швидкості гарантує, що порції не завершаться до Stop,
+	// а колбек прогресу повідомляє, що воркери вже працюють
+	started := make(chan struct{})
+	var once sync.Once
+	acc := NewAccumulator(8, WithSeed(1), WithThrottle(1e5), WithBatchSize(1000),
+		WithProgress(func(done, total int) { once.Do(func() { close(started) }) }))
+	errs := make(chan error, 4)
+	for i := 0; i < cap(errs); i++ {
+		go func() { errs <- acc.Add(1_000_000_000) }()
+	}
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("workers did not start in 5s")
+	}
+
+	acc.Stop()
+	acc.Stop()
+	for i := 0; i < cap(errs); i++ {
+		if err := <-errs; !errors.Is(err, ErrStopped) {
+			t.Errorf("interrupted Add returned %v, want ErrStopped", err)
+		}
+	}
+	if err := acc.Add(1000); !errors.Is(err, ErrStopped) {
+		t.Errorf("Add after Stop returned %v, want ErrStopped", err)
+	}
+	if acc.Total() == 0 {
+		t.Error("points of interrupted batches were not kept")
+	}
+	waitGoroutines(t, baseline)
+}
@@ -0,0 +1,65 @@
+package montecarlo
+
+import (
+	"fmt"
+	"math"
+)
+
+// Межі кількості точок для EstimatePiToAccuracy.
+const (
+	accuracyInitialPoints = 100000  // Кількість точок першої порції
+	accuracyMaxPoints     = 1 << 32 // Запобіжна межа загальної кількості точок
+)
+
+// PointsForAccuracy аналітично оцінює кількість точок, за якої стандартна похибка
+// оцінки PI дорівнює targetAbsError. Похибка спадає як c/sqrt(N), де
+// c = 4*sqrt(p*(1-p)) ≈ 1.64 для ймовірності влучення p = PI/4, тож N = (c/targetAbsError)².
+// Це стандартне відхилення, а не гарантія: приблизно в третині запусків
+// фактична похибка буде більшою. Обчислення не запускається.
+// Повертає помилку, якщо targetAbsError не додатне або кількість точок не вміщується в int.
+func PointsForAccuracy(targetAbsError float64) (int, error) {
+	if !(targetAbsError > 0) {
+		return 0, fmt.Errorf("targetAbsError must be > 0, got %g", targetAbsError)
+	}
+	p := math.Pi / 4
+	c := 4 * math.Sqrt(p*(1-p))
+	n := math.Ceil(math.Pow(c/targetAbsError, 2))
+	if n >= math.MaxInt {
+		return 0, fmt.Errorf("targetAbsError %g requires more than %d points", targetAbsError, math.MaxInt)
+	}
+	return int(n), nil
+}
+
+// EstimatePiToAccuracy додає порції точок до Accumulator, доки стандартна
+// похибка оцінки не стане меншою за targetStdErr. Після першої порції розмір
+// кожної наступної розраховується з поточної похибки: вона спадає як 1/sqrt(n),
+// тож потрібна кількість точок дорівнює n*(StdErr/targetStdErr)².
+// Загальна кількість використаних точок повертається в полі Total.
+// Якщо ціль не досягнуто за 2^32 точок, повертає поточну оцінку разом з помилкою.
+// Повертає помилку, якщо targetStdErr не додатне або кількість потоків менша за 1.
+func EstimatePiToAccuracy(targetStdErr float64, numThreads int, opts ...Option) (PiResult, error) {
+	if !(targetStdErr > 0) {
+		return PiResult{}, fmt.Errorf("targetStdErr must be > 0, got %g", targetStdErr)
+	}
+
+	acc := NewAccumulator(numThreads, opts...)
+	points := accuracyInitialPoints
+	for {
+		if err := acc.Add(points); err != nil {
+			return PiResult{}, err
+		}
+		res := acc.Result()
+		if res.StdErr < targetStdErr {
+			return res, nil
+		}
+		if res.Total >= accuracyMaxPoints {
+			return res, fmt.Errorf("target stderr %g not reached after %d points (stderr %g)",
+				targetStdErr, res.Total, res.StdErr)
+		}
+
+		// Запас 10% компенсує випадкові коливання похибки
+		needed := float64(res.Total) * math.Pow(res.StdErr/targetStdErr, 2) * 1.1
+		next := int64(min(needed, accuracyMaxPoints)) - res.Total
+		points = int(min(max(next, accuracyInitialPoints), accuracyMaxPoints-res.Total))
+	}
+}
@@ -0,0 +1,48 @@
+//go:build linux
+
+package montecarlo
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// cpuMask — маска процесорів для sched_setaffinity, до 1024 логічних CPU.
+type cpuMask [16]uint64
+
+// schedAffinity викликає sched_getaffinity або sched_setaffinity для поточного потоку ОС.
+func schedAffinity(trap uintptr, mask *cpuMask) error {
+	_, _, errno := syscall.RawSyscall(trap, 0, unsafe.Sizeof(*mask), uintptr(unsafe.Pointer(mask)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// pinToCPU прив'язує поточний потік ОС до одного з доступних йому процесорів,
+// обраного за порядковим номером горутини-воркера по колу, і повертає функцію, що відновлює попередню маску.
+// Викликається лише після runtime.LockOSThread.
+func pinToCPU(ordinal int) (restore func(), err error) {
+	var old cpuMask
+	if err := schedAffinity(syscall.SYS_SCHED_GETAFFINITY, &old); err != nil {
+		return nil, err
+	}
+
+	var cpus []int
+	for i := range len(old) * 64 {
+		if old[i/64]&(1<<(i%64)) != 0 {
+			cpus = append(cpus, i)
+		}
+	}
+	if len(cpus) == 0 {
+		return func() {}, nil
+	}
+
+	var mask cpuMask
+	cpu := cpus[ordinal%len(cpus)]
+	mask[cpu/64] = 1 << (cpu % 64)
+	if err := schedAffinity(syscall.SYS_SCHED_SETAFFINITY, &mask); err != nil {
+		return nil, err
+	}
+	return func() { schedAffinity(syscall.SYS_SCHED_SETAFFINITY, &old) }, nil
+}
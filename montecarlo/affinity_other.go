@@ -0,0 +1,8 @@
+//go:build !linux
+
+package montecarlo
+
+// pinToCPU нічого не робить: прив'язка до процесорів підтримується лише в Linux.
+func pinToCPU(ordinal int) (restore func(), err error) {
+	return func() {}, nil
+}
@@ -0,0 +1,42 @@
+package montecarlo
+
+import "math/rand/v2"
+
+// antitheticKernel створює kernelFactory, що до кожної випадкової точки (x, y)
+// додає її відображення (1-x, 1-y). Пара точок утворює одне випробування,
+// тож дисперсія оцінюється за парами, а не за окремими точками.
+// Якщо n не кратне двом, незавершена пара продовжується в наступному виклику.
+func antitheticKernel(region Region, o options) kernelFactory {
+	return func(r *rand.Rand) kernel {
+		coords := o.coordinates(r)
+
+		var (
+			pending bool    // Чи очікує відображена точка на перевірку
+			rx, ry  float64 // Відображена точка незавершеної пари
+			first   int     // Влучення першої точки незавершеної пари
+		)
+		return func(n int) workerResult {
+			res := workerResult{sampled: int64(n)}
+			for i := 0; i < n; i++ {
+				if !pending {
+					x, y := coords()
+					first = boolToInt(region(x, y))
+					res.inside += int64(first)
+					rx, ry = 1-x, 1-y
+					pending = true
+					continue
+				}
+
+				second := boolToInt(region(rx, ry))
+				res.inside += int64(second)
+
+				h := int64(first + second)
+				res.units++
+				res.unitInside += h
+				res.unitSq += h * h
+				pending = false
+			}
+			return res
+		}
+	}
+}
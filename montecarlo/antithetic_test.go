@@ -0,0 +1,26 @@
+package montecarlo
+
+import "testing"
+
+// TestAntitheticReducesStdErr порівнює стандартну похибку з WithAntithetic і без
+// при тій самій кількості точок. Очікуване зменшення — близько 15%, а оцінка
+// похибки за 10^6 точок точна до десятих часток відсотка, тож поріг 5% не хиткий.
+func TestAntitheticReducesStdErr(t *testing.T) {
+	const points = 1_000_000
+	for _, seed := range []int64{1, 2, 3} {
+		plain, err := EstimatePi(points, 4, WithSeed(seed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		anti, err := EstimatePi(points, 4, WithSeed(seed), WithAntithetic(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if anti.Total != points {
+			t.Errorf("seed %d: antithetic Total = %d, want %d", seed, anti.Total, points)
+		}
+		if ratio := anti.StdErr / plain.StdErr; ratio > 0.95 {
+			t.Errorf("seed %d: antithetic StdErr %v, plain %v (ratio %.3f)", seed, anti.StdErr, plain.StdErr, ratio)
+		}
+	}
+}
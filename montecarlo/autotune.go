@@ -0,0 +1,48 @@
+package montecarlo
+
+import "runtime"
+
+// autoCalibrationPoints — максимальна кількість точок калібрувального запуску.
+const autoCalibrationPoints = 200000
+
+// autoCandidates повертає кандидатів для автопідбору: степені двійки
+// до runtime.NumCPU()*2 включно, а також NumCPU та NumCPU*2.
+func autoCandidates() []int {
+	maxThreads := runtime.NumCPU() * 2
+	var candidates []int
+	for n := 1; n <= maxThreads; n *= 2 {
+		candidates = append(candidates, n)
+	}
+	for _, n := range []int{runtime.NumCPU(), maxThreads} {
+		if n&(n-1) != 0 { // Не степінь двійки, тож ще не доданий
+			candidates = append(candidates, n)
+		}
+	}
+	return candidates
+}
+
+// OptimalThreads підбирає кількість потоків з найбільшою пропускною здатністю
+// (точок за секунду) на цій машині. Для кожного кандидата, не більшого
+// за runtime.NumCPU()*2, виконується короткий калібрувальний запуск
+// на min(totalPoints, 200000) точок.
+func OptimalThreads(totalPoints int) int {
+	points := min(totalPoints, autoCalibrationPoints)
+	if points < 1 {
+		points = autoCalibrationPoints
+	}
+
+	best, bestThroughput := 1, 0.0
+	for _, n := range autoCandidates() {
+		res, err := Timed(func() (PiResult, error) {
+			return EstimatePi(points, n)
+		})
+		if err != nil || res.Elapsed <= 0 {
+			continue
+		}
+		throughput := float64(points) / res.Elapsed.Seconds()
+		if throughput > bestThroughput {
+			best, bestThroughput = n, throughput
+		}
+	}
+	return best
+}
@@ -0,0 +1,101 @@
+package montecarlo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// benchThreads — кількості потоків паралельних бенчмарків.
+var benchThreads = []int{1, 2, 4, 8, 16, 32, 64}
+
+// BenchmarkSequentialPi вимірює послідовне обчислення. Кількість точок
+// дорівнює b.N, тож ns/op — це час на одну точку:
+//
+//	go test ./montecarlo -run ^$ -bench Pi
+func BenchmarkSequentialPi(b *testing.B) {
+	EstimatePiSequential(b.N, WithSeed(1))
+}
+
+// BenchmarkParallelPi вимірює EstimatePi для кожної кількості потоків з benchThreads;
+// як і в BenchmarkSequentialPi, ns/op — це час на одну точку.
+func BenchmarkParallelPi(b *testing.B) {
+	for _, threads := range benchThreads {
+		b.Run(fmt.Sprintf("threads=%d", threads), func(b *testing.B) {
+			if _, err := EstimatePi(b.N, threads, WithSeed(1)); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+}
+
+// aggregateMutex — спосіб збору результатів для порівняння в BenchmarkAggregation:
+// як aggregateAtomic, але підсумки кожної порції додаються під sync.Mutex.
+func aggregateMutex(ctx context.Context, newKernel kernelFactory, parts []int, o options) workerResult {
+	var mu sync.Mutex
+	var total workerResult
+	var wg sync.WaitGroup
+
+	for i, pts := range parts {
+		wg.Add(1)
+		go func(index, pts int) {
+			defer wg.Done()
+			worker(ctx, newKernel, index, pts, o, func(res workerResult) {
+				mu.Lock()
+				total.add(res)
+				mu.Unlock()
+			})
+		}(i, pts)
+	}
+	wg.Wait()
+
+	return total
+}
+
+// BenchmarkAggregation порівнює збір результатів через канал, atomic.Int64
+// та sync.Mutex. Одна операція — обчислення 100000 точок з порціями по 1000,
+// тож при великій кількості потоків помітні накладні витрати самого збору:
+//
+//	go test ./montecarlo -run ^$ -bench Aggregation -count 10
+func BenchmarkAggregation(b *testing.B) {
+	const points = 100_000
+	strategies := []struct {
+		name      string
+		aggregate func(context.Context, kernelFactory, []int, options) workerResult
+	}{
+		{"channel", aggregateChannel},
+		{"atomic", aggregateAtomic},
+		{"mutex", aggregateMutex},
+	}
+	o := newOptions([]Option{WithSeed(1), WithBatchSize(1000)})
+	for _, s := range strategies {
+		for _, threads := range benchThreads {
+			b.Run(fmt.Sprintf("%s/threads=%d", s.name, threads), func(b *testing.B) {
+				parts := splitPoints(points, threads)
+				for i := 0; i < b.N; i++ {
+					if res := s.aggregate(context.Background(), circleKernel, parts, o); res.sampled != points {
+						b.Fatalf("sampled %d points, want %d", res.sampled, points)
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkPrecision порівнює пропускну здатність координат float64 та
+// float32 (WithFloat32) для кожної кількості потоків з benchThreads;
+// ns/op — це час на одну точку:
+//
+//	go test ./montecarlo -run ^$ -bench Precision -count 10
+func BenchmarkPrecision(b *testing.B) {
+	for _, bits := range []int{64, 32} {
+		for _, threads := range benchThreads {
+			b.Run(fmt.Sprintf("float%d/threads=%d", bits, threads), func(b *testing.B) {
+				if _, err := EstimatePi(b.N, threads, WithSeed(1), WithFloat32(bits == 32)); err != nil {
+					b.Fatal(err)
+				}
+			})
+		}
+	}
+}
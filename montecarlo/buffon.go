@@ -0,0 +1,54 @@
+package montecarlo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// buffonKernel — kernel для голки Бюффона. Довжина голки дорівнює відстані
+// між лініями (1). Відстань d від центру голки до найближчої лінії рівномірна
+// на [0, 1/2], а голка перетинає лінію, якщо d <= sin(θ)/2.
+// Синус кута береться з випадкового напрямку, обраного відкиданням точок
+// поза одиничним колом, тож сама генерація не використовує math.Pi.
+func buffonKernel(r *rand.Rand) kernel {
+	return func(n int) workerResult {
+		crossings := 0
+		for i := 0; i < n; i++ {
+			d := r.Float64() / 2
+
+			var u, v, lenSq float64
+			for {
+				u = r.Float64()
+				v = r.Float64()
+				lenSq = u*u + v*v
+				if lenSq <= 1.0 && lenSq > 0 {
+					break
+				}
+			}
+			sin := v / math.Sqrt(lenSq)
+
+			if d <= sin/2 {
+				crossings++
+			}
+		}
+		return pointResult(n, crossings)
+	}
+}
+
+// BuffonNeedlePi оцінює PI методом голки Бюффона: ймовірність перетину лінії
+// голкою, довжина якої дорівнює відстані між лініями, становить 2/PI,
+// тож PI ≈ 2 * кількість голок / кількість перетинів.
+// Голки кидаються паралельно в numThreads горутинах за тією ж схемою, що й у EstimatePi.
+// Якщо жодна голка не перетнула лінію, повертає NaN та помилку.
+func BuffonNeedlePi(numNeedles, numThreads int, opts ...Option) (float64, error) {
+	total, err := run(context.Background(), buffonKernel, numNeedles, numThreads, newOptions(opts))
+	if err != nil {
+		return 0, err
+	}
+	if total.inside == 0 {
+		return math.NaN(), fmt.Errorf("no needle crossed a line among %d needles", total.sampled)
+	}
+	return 2.0 * float64(total.sampled) / float64(total.inside), nil
+}
@@ -0,0 +1,46 @@
+package montecarlo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// durationMaxPoints — запобіжна межа кількості точок EstimatePiForDuration;
+// половина math.MaxInt, щоб розрахунок кількості порцій не переповнювався.
+const durationMaxPoints = math.MaxInt / 2
+
+// EstimatePiForDuration генерує точки в numThreads горутинах, доки не мине d,
+// і повертає оцінку за всіма згенерованими точками. Воркери перевіряють
+// спільний дедлайн після кожної порції (див. WithBatchSize), тож фактичний час
+// перевищує d щонайбільше на час обробки однієї порції. Кількість точок
+// повертається в полі Total, а фактичний час і пропускна здатність — у полях
+// Elapsed та Throughput, тож результати різних машин можна порівнювати за
+// однаковий час. WithProgress не викликається, бо загальна кількість точок невідома.
+// Повертає помилку, якщо d не додатне або кількість потоків менша за 1.
+func EstimatePiForDuration(d time.Duration, numThreads int, opts ...Option) (PiResult, error) {
+	if d <= 0 {
+		return PiResult{}, fmt.Errorf("duration must be > 0, got %s", d)
+	}
+
+	o := newOptions(opts)
+	o.progressFn = nil
+
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	start := time.Now()
+	total, err := run(ctx, o.circleKernel(), durationMaxPoints, numThreads, o)
+	elapsed := time.Since(start)
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return PiResult{}, err
+	}
+
+	res := total.piResult(o.unitSize())
+	res.Threads = numThreads
+	res.Elapsed = elapsed
+	res.Throughput = float64(res.Total) / elapsed.Seconds()
+	return res, nil
+}
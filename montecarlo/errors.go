@@ -0,0 +1,38 @@
+package montecarlo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Помилки, з якими можна порівнювати результат функцій пакета через errors.Is.
+// Помилки валідації означають некоректні аргументи, і повторювати виклик немає сенсу;
+// ErrCanceled означає, що обчислення перервано через контекст, і його можна повторити.
+var (
+	ErrInvalidThreads = errors.New("invalid numThreads")
+	ErrInvalidPoints  = errors.New("invalid totalPoints")
+	ErrCanceled       = errors.New("estimation canceled")
+)
+
+// validateRun перевіряє кількість точок і потоків обчислення.
+func validateRun(totalPoints, numThreads int) error {
+	if numThreads < 1 {
+		return fmt.Errorf("%w: must be >= 1, got %d", ErrInvalidThreads, numThreads)
+	}
+	return validatePoints(totalPoints)
+}
+
+// validatePoints перевіряє кількість точок обчислення.
+func validatePoints(totalPoints int) error {
+	if totalPoints < 1 {
+		return fmt.Errorf("%w: must be >= 1, got %d", ErrInvalidPoints, totalPoints)
+	}
+	return nil
+}
+
+// canceled обгортає помилку контексту ctx, тож errors.Is спрацьовує
+// як для ErrCanceled, так і для context.Canceled чи context.DeadlineExceeded.
+func canceled(ctx context.Context) error {
+	return fmt.Errorf("%w: %w", ErrCanceled, ctx.Err())
+}
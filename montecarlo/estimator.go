@@ -0,0 +1,96 @@
+package montecarlo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+// Config описує параметри обчислення PI для NewEstimator.
+// Нульові значення необов'язкових полів означають поведінку за замовчуванням.
+type Config struct {
+	Points  int // Загальна кількість точок, не менше 1
+	Threads int // Кількість горутин, не менше 1
+
+	// Seed — базове зерно генератора (див. WithSeed); 0 означає зерно з поточного часу,
+	// якщо не встановлено SeedSet
+	Seed    int64
+	SeedSet bool // Seed задано явно, тож і нульове зерно використовується як фіксоване
+
+	Antithetic        bool // Антитетична вибірка (див. WithAntithetic)
+	Stratified        int  // Розмір сітки стратифікованої вибірки; 0 — вимкнено (див. WithStratified)
+	PackedCoordinates bool // Обидві координати з одного виклику генератора (див. WithPackedCoordinates)
+	ChunkSize         int  // Кількість точок в одному завданні; 0 — рівний поділ (див. WithChunkSize)
+
+	// RandFactory створює генератор кожного воркера; nil — генератор за замовчуванням (див. WithRandFactory)
+	RandFactory func(workerIndex int) *rand.Rand
+}
+
+// options переводить Config у набір Option.
+func (cfg Config) options() []Option {
+	opts := []Option{
+		WithAntithetic(cfg.Antithetic),
+		WithStratified(cfg.Stratified),
+		WithPackedCoordinates(cfg.PackedCoordinates),
+		WithChunkSize(cfg.ChunkSize),
+	}
+	if cfg.Seed != 0 || cfg.SeedSet {
+		opts = append(opts, WithSeed(cfg.Seed))
+	}
+	if cfg.RandFactory != nil {
+		opts = append(opts, WithRandFactory(cfg.RandFactory))
+	}
+	return opts
+}
+
+// Estimator виконує обчислення PI з перевіреною конфігурацією.
+// Кожен виклик Run незалежний, тож Estimator можна використовувати повторно
+// та з кількох горутин одночасно.
+type Estimator struct {
+	cfg Config
+}
+
+// Validate перевіряє всі поля cfg одразу і повертає об'єднану через errors.Join
+// помилку з кожною знайденою проблемою, або nil, якщо конфігурація коректна.
+// Помилки кількості точок і потоків обгортають ErrInvalidPoints та ErrInvalidThreads.
+// Seed може бути будь-яким: 0 без SeedSet означає зерно з поточного часу.
+func (cfg Config) Validate() error {
+	var errs []error
+	if cfg.Threads < 1 {
+		errs = append(errs, fmt.Errorf("%w: must be >= 1, got %d", ErrInvalidThreads, cfg.Threads))
+	}
+	if err := validatePoints(cfg.Points); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.Stratified < 0 {
+		errs = append(errs, fmt.Errorf("stratified grid size must be >= 0, got %d", cfg.Stratified))
+	}
+	if cfg.ChunkSize < 0 {
+		errs = append(errs, fmt.Errorf("chunk size must be >= 0, got %d", cfg.ChunkSize))
+	}
+	return errors.Join(errs...)
+}
+
+// NewEstimator перевіряє cfg через Validate і створює Estimator.
+func NewEstimator(cfg Config) (*Estimator, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Estimator{cfg: cfg}, nil
+}
+
+// Config повертає конфігурацію Estimator.
+func (e *Estimator) Config() Config {
+	return e.cfg
+}
+
+// Run обчислює PI паралельно за конфігурацією Estimator, як EstimatePi.
+// Конфігурацію перевірено в NewEstimator, тож обчислення не може завершитися помилкою.
+func (e *Estimator) Run() PiResult {
+	o := newOptions(e.cfg.options())
+	total, _ := run(context.Background(), o.circleKernel(), e.cfg.Points, e.cfg.Threads, o)
+	res := total.piResult(o.unitSize())
+	res.Threads = e.cfg.Threads
+	return res
+}
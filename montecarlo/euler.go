@@ -0,0 +1,37 @@
+package montecarlo
+
+import (
+	"context"
+	"math/rand/v2"
+)
+
+// eulerKernel — kernel для оцінки числа e. Кожне випробування додає рівномірні
+// на [0, 1) числа, доки сума не перевищить 1, і рахує кількість доданків.
+// Поле inside підсумовує кількість доданків усіх випробувань, а sampled — кількість випробувань.
+func eulerKernel(r *rand.Rand) kernel {
+	return func(n int) workerResult {
+		res := workerResult{sampled: int64(n), units: int64(n)}
+		for i := 0; i < n; i++ {
+			var count int64
+			for sum := 0.0; sum <= 1.0; count++ {
+				sum += r.Float64()
+			}
+			res.inside += count
+			res.unitInside += count
+			res.unitSq += count * count
+		}
+		return res
+	}
+}
+
+// EstimateE оцінює число e: математичне сподівання кількості рівномірних
+// на [0, 1) чисел, сума яких уперше перевищує 1, дорівнює e.
+// Випробування виконуються паралельно в numThreads горутинах за тією ж схемою, що й у EstimatePi.
+// Повертає помилку, якщо кількість випробувань чи потоків менша за 1.
+func EstimateE(numTrials, numThreads int, opts ...Option) (float64, error) {
+	total, err := run(context.Background(), eulerKernel, numTrials, numThreads, newOptions(opts))
+	if err != nil {
+		return 0, err
+	}
+	return float64(total.inside) / float64(total.sampled), nil
+}
@@ -0,0 +1,21 @@
+package montecarlo
+
+import "math/rand/v2"
+
+// circle32 повідомляє, чи лежить точка (x, y) float32 у чверті одиничного кола.
+func circle32(x, y float32) bool {
+	return x*x+y*y <= 1.0
+}
+
+// float32CircleKernel — варіант circleKernel з координатами та арифметикою float32.
+func float32CircleKernel(r *rand.Rand) kernel {
+	return func(n int) workerResult {
+		inside := 0
+		for i := 0; i < n; i++ {
+			x := r.Float32()
+			y := r.Float32()
+			inside += boolToInt(circle32(x, y))
+		}
+		return pointResult(n, inside)
+	}
+}
@@ -0,0 +1,116 @@
+package montecarlo
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// WithHistogram вмикає підрахунок того, куди потрапляють точки: одиничний
+// квадрат ділиться на сітку size×size клітинок, і після завершення обчислення
+// fn один раз отримує кількість точок у кожній клітинці як cells[y][x],
+// де рядок 0 відповідає найменшим y. Поки локальні сітки всіх воркерів разом
+// займають не більше 32 МіБ (numThreads*size²*8 байт), кожен воркер рахує точки
+// у власній сітці і додає її до спільної після кожної порції; інакше всі воркери
+// атомарно додають кожну точку до спільної сітки. Тож пам'ять обмежена
+// 32 МіБ + size²*8 байт незалежно від кількості потоків, а час зростає на кілька
+// операцій на точку. Режим вимкнений за замовчуванням.
+// З Accumulator сітка накопичується між викликами Add, і fn викликається після кожного з них.
+// Гістограма використовує звичайну вибірку (з урахуванням WithPackedCoordinates)
+// і має пріоритет над WithAntithetic та WithStratified.
+// Значення size менше за 1 або nil fn вимикають режим.
+// Колбек викликається з горутини, що викликала функцію оцінки.
+func WithHistogram(size int, fn func(cells [][]int)) Option {
+	return func(o *options) {
+		o.histogramSize = size
+		o.histogramFn = fn
+	}
+}
+
+// histogramLocalBudget — найбільший сумарний розмір локальних сіток воркерів у байтах.
+const histogramLocalBudget = 32 << 20
+
+// histogramTracker збирає сітки воркерів одного обчислення.
+type histogramTracker struct {
+	size int
+	fn   func(cells [][]int)
+
+	cells  []atomic.Int64 // Спільні лічильники клітинок рядками по size
+	shared atomic.Bool    // Чи рахують воркери точки одразу у спільній сітці
+}
+
+// newHistogramTracker створює трекер або повертає nil, якщо режим вимкнено.
+func newHistogramTracker(size int, fn func(cells [][]int)) *histogramTracker {
+	if size < 1 || fn == nil {
+		return nil
+	}
+	return &histogramTracker{size: size, fn: fn, cells: make([]atomic.Int64, size*size)}
+}
+
+// plan обирає спосіб підрахунку для обчислення з numWorkers воркерами:
+// локальні сітки, якщо вони вміщуються в histogramLocalBudget, інакше спільну.
+// Викликається до запуску воркерів. Безпечний для nil-трекера.
+func (h *histogramTracker) plan(numWorkers int) {
+	if h == nil {
+		return
+	}
+	h.shared.Store(numWorkers*h.size*h.size*8 > histogramLocalBudget)
+}
+
+// merge атомарно додає до спільної сітки локальну сітку воркера.
+func (h *histogramTracker) merge(local []int) {
+	for i, n := range local {
+		if n != 0 {
+			h.cells[i].Add(int64(n))
+		}
+	}
+}
+
+// report передає колбеку копію спільної сітки. Безпечний для nil-трекера.
+func (h *histogramTracker) report() {
+	if h == nil {
+		return
+	}
+	cells := make([][]int, h.size)
+	for y := range cells {
+		cells[y] = make([]int, h.size)
+		for x := range cells[y] {
+			cells[y][x] = int(h.cells[y*h.size+x].Load())
+		}
+	}
+	h.fn(cells)
+}
+
+// histogramKernel створює kernelFactory для області region, що додатково
+// рахує точки в клітинках сітки h: у локальній сітці воркера, яка додається
+// до спільної і обнуляється після кожної порції, або, якщо так обрав plan,
+// атомарними додаваннями одразу до спільної.
+func histogramKernel(region Region, h *histogramTracker, o options) kernelFactory {
+	scale := float64(h.size)
+	return func(r *rand.Rand) kernel {
+		coords := o.coordinates(r)
+		if h.shared.Load() {
+			return func(n int) workerResult {
+				inside := 0
+				for i := 0; i < n; i++ {
+					x, y := coords()
+					h.cells[int(y*scale)*h.size+int(x*scale)].Add(1)
+					inside += boolToInt(region(x, y))
+				}
+				return pointResult(n, inside)
+			}
+		}
+
+		local := make([]int, h.size*h.size)
+		return func(n int) workerResult {
+			inside := 0
+			for i := 0; i < n; i++ {
+				x, y := coords()
+				local[int(y*scale)*h.size+int(x*scale)]++
+				inside += boolToInt(region(x, y))
+			}
+			h.merge(local)
+			clear(local)
+			return pointResult(n, inside)
+		}
+	}
+}
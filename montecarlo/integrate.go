@@ -0,0 +1,59 @@
+package montecarlo
+
+import (
+	"context"
+	"fmt"
+)
+
+// EstimateArea оцінює площу області region всередині одиничного квадрата,
+// тобто частку точок, для яких region повертає true, використовуючи ту саму
+// паралельну схему, що й EstimatePi. Для чверті кола x²+y²<=1 площа дорівнює PI/4.
+// Повертає помилку, якщо кількість точок чи потоків менша за 1.
+func EstimateArea(region Region, totalPoints, numThreads int, opts ...Option) (float64, error) {
+	o := newOptions(opts)
+	total, err := run(context.Background(), o.regionKernel(region), totalPoints, numThreads, o)
+	if err != nil {
+		return 0, err
+	}
+	return float64(total.inside) / float64(total.sampled), nil
+}
+
+// EstimateCircleArea оцінює площу частини кола радіусом radius з центром
+// у початку координат, що лежить у прямокутнику [-boundX, boundX] × [-boundY, boundY].
+// Завдяки симетрії точки генеруються лише в чверті [0, boundX] × [0, boundY]
+// через EstimateArea, а площа дорівнює частці влучень, помноженій на площу
+// прямокутника 4*boundX*boundY. Якщо прямокутник містить усе коло, результат є
+// оцінкою PI*radius²; при radius = boundX = boundY = 1 повертається оцінка PI.
+// Повертає помилку, якщо radius, boundX чи boundY не додатні,
+// або кількість точок чи потоків менша за 1.
+func EstimateCircleArea(radius, boundX, boundY float64, totalPoints, numThreads int, opts ...Option) (float64, error) {
+	if !(radius > 0) {
+		return 0, fmt.Errorf("radius must be > 0, got %g", radius)
+	}
+	if !(boundX > 0) || !(boundY > 0) {
+		return 0, fmt.Errorf("bounds must be > 0, got %g x %g", boundX, boundY)
+	}
+
+	r2 := radius * radius
+	region := func(u, v float64) bool {
+		x, y := u*boundX, v*boundY
+		return x*x+y*y <= r2
+	}
+	fraction, err := EstimateArea(region, totalPoints, numThreads, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return fraction * 4 * boundX * boundY, nil
+}
+
+// Integrate оцінює частку одиничного квадрата, в якій f повертає true,
+// помножену на 4, використовуючи ту саму паралельну схему, що й EstimatePi.
+// Для чверті кола x²+y²<=1 результат є оцінкою PI.
+func Integrate(f func(x, y float64) bool, totalPoints, numThreads int, opts ...Option) (float64, error) {
+	o := newOptions(opts)
+	total, err := run(context.Background(), o.regionKernel(f), totalPoints, numThreads, o)
+	if err != nil {
+		return 0, err
+	}
+	return piFromCounts(total.inside, total.sampled), nil
+}
@@ -0,0 +1,75 @@
+package montecarlo
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// branchCircleKernel — варіант circleKernel з умовним переходом замість boolToInt,
+// залишений для порівняння в BenchmarkCircleKernel.
+func branchCircleKernel(r *rand.Rand) kernel {
+	return func(n int) workerResult {
+		inside := 0
+		for i := 0; i < n; i++ {
+			x := r.Float64()
+			y := r.Float64()
+			if circle(x, y) {
+				inside++
+			}
+		}
+		return pointResult(n, inside)
+	}
+}
+
+// benchKernel вимірює kernel, створений newKernel, на b.N точках, тож ns/op — це час на точку.
+func benchKernel(b *testing.B, newKernel kernelFactory) {
+	sample := newKernel(rand.New(rand.NewPCG(1, 0)))
+	b.ResetTimer()
+	if res := sample(b.N); res.sampled != int64(b.N) {
+		b.Fatalf("sampled %d points, want %d", res.sampled, b.N)
+	}
+}
+
+// BenchmarkCircleKernel порівнює перевірку влучення з умовним переходом
+// та без нього (boolToInt), за яким вибрано форму circleKernel:
+//
+//	go test ./montecarlo -run ^$ -bench CircleKernel -count 10
+func BenchmarkCircleKernel(b *testing.B) {
+	b.Run("branch", func(b *testing.B) { benchKernel(b, branchCircleKernel) })
+	b.Run("branchless", func(b *testing.B) { benchKernel(b, circleKernel) })
+}
+
+// bufferSize — кількість значень буфера bufferedCircleKernel.
+const bufferSize = 8192
+
+// bufferedCircleKernel — варіант circleKernel, що спершу заповнює буфер
+// випадковими значеннями, а потім читає з нього пари координат. Залишений
+// для порівняння в BenchmarkBufferedKernel.
+func bufferedCircleKernel(r *rand.Rand) kernel {
+	buf := make([]float64, bufferSize)
+	return func(n int) workerResult {
+		inside := 0
+		for done := 0; done < n; {
+			m := min(bufferSize/2, n-done)
+			coords := buf[:2*m]
+			for i := range coords {
+				coords[i] = r.Float64()
+			}
+			for i := 0; i < len(coords); i += 2 {
+				inside += boolToInt(circle(coords[i], coords[i+1]))
+			}
+			done += m
+		}
+		return pointResult(n, inside)
+	}
+}
+
+// BenchmarkBufferedKernel порівнює читання координат з попередньо заповненого
+// буфера з генерацією кожної координати безпосередньо перед перевіркою
+// (circleKernel), за яким буферизацію відхилено:
+//
+//	go test ./montecarlo -run ^$ -bench BufferedKernel -count 10
+func BenchmarkBufferedKernel(b *testing.B) {
+	b.Run("interleaved", func(b *testing.B) { benchKernel(b, circleKernel) })
+	b.Run("buffered", func(b *testing.B) { benchKernel(b, bufferedCircleKernel) })
+}
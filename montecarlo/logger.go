@@ -0,0 +1,23 @@
+package montecarlo
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithLogger задає логер для діагностичних повідомлень бібліотеки:
+// параметрів запуску обчислення та його підсумків (рівень Debug).
+// За замовчуванням повідомлення відкидаються, тож бібліотека нічого не виводить.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// discardHandler — slog.Handler, що відкидає всі записи.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
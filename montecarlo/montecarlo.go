@@ -0,0 +1,412 @@
+// Пакет montecarlo реалізує обчислення числа PI методом Монте-Карло,
+// як послідовно, так і паралельно за допомогою горутин.
+//
+// Кожен воркер має власний генератор випадкових чисел, а спільний стан
+// обмежується каналами, атомарними лічильниками та м'ютексами.
+package montecarlo
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultBatchSize — розмір порції точок воркера за замовчуванням.
+const defaultBatchSize = 1 << 16
+
+// defaultDynamicChunkSize — розмір порції динамічного розподілу за замовчуванням.
+const defaultDynamicChunkSize = 100_000
+
+// workerResult містить результат роботи одного або кількох воркерів.
+// Випробування — група точок, що оцінюються разом (одна точка у звичайному
+// режимі, пара точок в антитетичному); лічильники завершених випробувань
+// використовуються для оцінки дисперсії.
+type workerResult struct {
+	inside     int64 // Кількість точок, що потрапили в область
+	sampled    int64 // Кількість фактично згенерованих точок
+	units      int64 // Кількість завершених випробувань
+	unitInside int64 // Кількість влучень у завершених випробуваннях
+	unitSq     int64 // Сума квадратів кількості влучень завершених випробувань
+}
+
+// add додає до w підсумки other.
+func (w *workerResult) add(other workerResult) {
+	w.inside += other.inside
+	w.sampled += other.sampled
+	w.units += other.units
+	w.unitInside += other.unitInside
+	w.unitSq += other.unitSq
+}
+
+// EstimatePiSequential обчислює PI послідовно в одному потоці.
+func EstimatePiSequential(numPoints int, opts ...Option) PiResult {
+	o := newOptions(opts)
+
+	r := o.newRand(0)
+	var insideCircle int64
+
+	for i := 0; i < numPoints; i++ {
+		x := r.Float64()
+		y := r.Float64()
+
+		// Перевіряємо, чи точка потрапила в коло з радіусом 1
+		if x*x+y*y <= 1.0 {
+			insideCircle++
+		}
+	}
+
+	res := newPiResult(insideCircle, int64(numPoints))
+	res.Threads = 1
+	return res
+}
+
+// worker обчислює підсумки kernel, створеного newKernel, для заданої кількості точок.
+// Використовує окремий генератор rand для кожної горутини, щоб уникнути race condition.
+// Точки обробляються порціями по o.batchSize: підсумки кожної порції
+// накопичуються локально і передаються у flush, після чого оновлюється
+// прогрес і перевіряється ctx. При скасуванні воркер зупиняється після поточної порції.
+// З WithThrottle після кожної порції воркер чекає, доки не буде досягнуто задану швидкість.
+// Індекс визначає лише генератор; прив'язку до процесора виконує горутина через pinWorker.
+func worker(ctx context.Context, newKernel kernelFactory, index, numPoints int, o options, flush func(workerResult)) {
+	// Для кожної горутини використовується окремий генератор,
+	// щоб уникнути синхронізації при генерації випадкових чисел.
+	sample := newKernel(o.newRand(index))
+	limit := newThrottle(o.throttleRate)
+
+	for done := 0; done < numPoints; {
+		if ctx.Err() != nil {
+			break
+		}
+
+		batch := limit.batch(min(o.batchSize, numPoints-done))
+		res := sample(batch)
+		flush(res)
+		o.snapshots.add(res)
+		done += batch
+		o.progress.add(batch)
+		limit.wait(ctx, done)
+	}
+}
+
+// pinWorker з WithCPUAffinity закріплює поточну горутину за потоком ОС і прив'язує
+// цей потік до процесора за порядковим номером горутини ordinal, а без неї нічого не робить.
+// Викликається один раз на горутину до обробки її порцій, тож з WithChunkSize
+// чи WithDynamicScheduling горутина не переходить на інший процесор з кожною порцією.
+// Повернена функція знімає прив'язку і має викликатися в тій самій горутині.
+func (o options) pinWorker(ordinal int) (unpin func()) {
+	if !o.cpuAffinity {
+		return func() {}
+	}
+	runtime.LockOSThread()
+	restore, err := pinToCPU(ordinal)
+	if err != nil {
+		o.logger.Debug("cpu affinity not applied", "worker", ordinal, "error", err)
+		return runtime.UnlockOSThread
+	}
+	return func() {
+		restore()
+		runtime.UnlockOSThread()
+	}
+}
+
+// EstimatePi обчислює PI, розбиваючи роботу на numThreads горутин.
+// Глобальне значення GOMAXPROCS не змінюється: горутини розподіляє планувальник Go,
+// тому функцію безпечно викликати конкурентно.
+// З одним потоком (без WithChunkSize та WithDynamicScheduling) точки обчислюються в поточній горутині.
+// Повертає отриманий результат, або помилку ErrInvalidPoints чи ErrInvalidThreads,
+// якщо кількість точок чи потоків менша за 1.
+// Час обчислення не вимірюється; для цього використовуйте Timed.
+func EstimatePi(totalPoints, numThreads int, opts ...Option) (PiResult, error) {
+	return EstimatePiContext(context.Background(), totalPoints, numThreads, opts...)
+}
+
+// EstimatePiContext працює як EstimatePi, але може бути перерваний через ctx.
+// При скасуванні повертає оцінку PI за фактично згенерованими точками разом з помилкою,
+// що обгортає ErrCanceled та ctx.Err().
+func EstimatePiContext(ctx context.Context, totalPoints, numThreads int, opts ...Option) (PiResult, error) {
+	o := newOptions(opts)
+	total, err := run(ctx, o.circleKernel(), totalPoints, numThreads, o)
+	if err != nil && total.sampled == 0 {
+		return PiResult{}, err
+	}
+
+	// Фінальне обчислення PI за фактично згенерованими точками
+	res := total.piResult(o.unitSize())
+	res.Threads = numThreads
+	return res, err
+}
+
+// run перевіряє параметри, розподіляє точки між numThreads воркерами
+// та повертає їхні сумарні лічильники. Якщо ctx скасовано до завершення,
+// повертає часткові лічильники разом з помилкою, що обгортає ErrCanceled та ctx.Err().
+func run(ctx context.Context, newKernel kernelFactory, totalPoints, numThreads int, o options) (workerResult, error) {
+	if err := validateRun(totalPoints, numThreads); err != nil {
+		return workerResult{}, err
+	}
+
+	o.progress = newProgressTracker(o.progressFn, totalPoints)
+	o.timing = newTimingTracker(o.timingFn)
+	o.histogram.plan(min(numThreads, totalPoints))
+	o.snapshots = newSnapshotTracker(o.snapshotCh, o.snapshotEvery, o.unitSize(), numThreads)
+	stopSnapshots := o.snapshots.start()
+	o.logger.Debug("estimation started", "points", totalPoints, "threads", numThreads,
+		"seed", o.seed, "chunk_size", o.chunkSize, "batch_size", o.batchSize)
+
+	var total workerResult
+	switch {
+	case o.dynamic:
+		total = aggregateDynamic(ctx, newKernel, totalPoints, numThreads, o)
+	case o.chunkSize > 0:
+		total = aggregateChunks(ctx, newKernel, totalPoints, numThreads, o)
+	case numThreads == 1:
+		total = aggregateInline(ctx, newKernel, totalPoints, o)
+	case o.aggregation == AggregateAtomic:
+		total = aggregateAtomic(ctx, newKernel, splitPoints(totalPoints, numThreads), o)
+	default:
+		total = aggregateChannel(ctx, newKernel, splitPoints(totalPoints, numThreads), o)
+	}
+
+	stopSnapshots()
+	o.timing.report()
+	o.histogram.report()
+	o.logger.Debug("estimation finished", "inside", total.inside, "sampled", total.sampled)
+	if total.sampled < int64(totalPoints) {
+		return total, canceled(ctx)
+	}
+	return total, nil
+}
+
+// SplitPoints повертає кількість точок кожного воркера при рівному поділі
+// totalPoints точок між numThreads потоками, як це робить EstimatePi:
+// залишок рівномірно розкидається між воркерами. Якщо точок менше, ніж потоків,
+// воркерів стільки, скільки точок. Обчислення не запускається.
+// Повертає помилку, якщо кількість точок чи потоків менша за 1.
+func SplitPoints(totalPoints, numThreads int) ([]int, error) {
+	if err := validateRun(totalPoints, numThreads); err != nil {
+		return nil, err
+	}
+	return splitPoints(totalPoints, numThreads), nil
+}
+
+// splitPoints розподіляє totalPoints точок між numThreads потоками.
+// Частина i-го потоку — це різниця меж boundary(i+1) - boundary(i),
+// тому залишок рівномірно розкидається між потоками, а не дістається першим,
+// і сума частин завжди точно дорівнює totalPoints.
+// Якщо точок менше, ніж потоків, кількість частин обмежується totalPoints,
+// щоб не запускати воркерів без роботи.
+func splitPoints(totalPoints, numThreads int) []int {
+	numThreads = min(numThreads, totalPoints)
+	pointsPerWorker := totalPoints / numThreads
+	remainder := totalPoints % numThreads
+
+	// boundary(i) = i*totalPoints/numThreads без переповнення при великих totalPoints
+	boundary := func(i int) int {
+		return i*pointsPerWorker + i*remainder/numThreads
+	}
+
+	parts := make([]int, numThreads)
+	for i := range parts {
+		parts[i] = boundary(i+1) - boundary(i)
+	}
+	return parts
+}
+
+// chunkCount повертає кількість порцій розміром chunkSize, що покривають totalPoints точок.
+func chunkCount(totalPoints, chunkSize int) int {
+	return (totalPoints + chunkSize - 1) / chunkSize
+}
+
+// chunkPoints повертає кількість точок у порції з індексом index.
+// Остання порція може бути меншою за chunkSize.
+func chunkPoints(index, totalPoints, chunkSize int) int {
+	return min(chunkSize, totalPoints-index*chunkSize)
+}
+
+// aggregateChunks ділить totalPoints на порції по o.chunkSize точок, які
+// numThreads горутин забирають з каналу, доки порції не закінчаться.
+// Кожна порція отримує власний генератор за своїм індексом, тож результат
+// при фіксованому зерні не залежить від того, яка горутина її обробила.
+func aggregateChunks(ctx context.Context, newKernel kernelFactory, totalPoints, numThreads int, o options) workerResult {
+	numChunks := chunkCount(totalPoints, o.chunkSize)
+	numWorkers := min(numThreads, numChunks)
+
+	chunks := make(chan int) // Індекси порцій
+	go func() {
+		defer close(chunks)
+		for i := 0; i < numChunks; i++ {
+			select {
+			case chunks <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	resultChan := make(chan workerResult, numWorkers)
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unpin := o.pinWorker(w)
+			defer unpin()
+			stop := o.timing.start()
+			var local workerResult
+			for i := range chunks {
+				worker(ctx, newKernel, i, chunkPoints(i, totalPoints, o.chunkSize), o, local.add)
+			}
+			stop()
+			resultChan <- local
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	var total workerResult
+	for res := range resultChan {
+		total.add(res)
+	}
+	return total
+}
+
+// aggregateDynamic запускає numThreads горутин, кожна з яких захоплює
+// наступну порцію атомарним збільшенням спільного лічильника і обробляє її,
+// доки порції не закінчаться. На відміну від aggregateChunks, роздавальна
+// горутина та канал не потрібні.
+func aggregateDynamic(ctx context.Context, newKernel kernelFactory, totalPoints, numThreads int, o options) workerResult {
+	chunkSize := o.chunkSize
+	if chunkSize < 1 {
+		chunkSize = defaultDynamicChunkSize
+	}
+	numChunks := chunkCount(totalPoints, chunkSize)
+	numWorkers := min(numThreads, numChunks)
+
+	var next atomic.Int64 // Індекс наступної вільної порції
+	var total atomicResult
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unpin := o.pinWorker(w)
+			defer unpin()
+			stop := o.timing.start()
+			var local workerResult
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= numChunks || ctx.Err() != nil {
+					break
+				}
+				worker(ctx, newKernel, i, chunkPoints(i, totalPoints, chunkSize), o, local.add)
+			}
+			stop()
+			total.add(local)
+		}()
+	}
+	wg.Wait()
+
+	return total.load()
+}
+
+// aggregateInline обчислює всі точки одним воркером у поточній горутині,
+// без горутин, каналу та WaitGroup, тож час обчислення з одним потоком
+// не завищується їхніми накладними витратами. Воркер має індекс 0, як
+// і єдиний воркер інших способів збору, тож результат не відрізняється,
+// а за звичайної вибірки збігається з EstimatePiSequential при тому самому зерні.
+func aggregateInline(ctx context.Context, newKernel kernelFactory, totalPoints int, o options) workerResult {
+	unpin := o.pinWorker(0)
+	defer unpin()
+	stop := o.timing.start()
+	var total workerResult
+	worker(ctx, newKernel, 0, totalPoints, o, total.add)
+	stop()
+	return total
+}
+
+// aggregateChannel запускає по горутині на кожну частину і збирає результати через канал.
+func aggregateChannel(ctx context.Context, newKernel kernelFactory, parts []int, o options) workerResult {
+	resultChan := make(chan workerResult, len(parts)) // Канал для збору результатів
+	var wg sync.WaitGroup                             // WaitGroup для з'єднання горутин
+
+	// Запуск горутин
+	for i, pts := range parts {
+		wg.Add(1)
+		go func(index, pts int) {
+			defer wg.Done()
+			unpin := o.pinWorker(index)
+			defer unpin()
+			stop := o.timing.start()
+			// Порції накопичуються локально, а в канал відправляється один підсумок
+			var local workerResult
+			worker(ctx, newKernel, index, pts, o, local.add)
+			stop()
+			resultChan <- local
+		}(i, pts)
+	}
+
+	// Асинхронне закриття каналу після завершення всіх горутин (аналог join)
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	// Збір результатів з каналу
+	var total workerResult
+	for res := range resultChan {
+		total.add(res)
+	}
+	return total
+}
+
+// aggregateAtomic запускає по горутині на кожну частину, і кожна з них після
+// кожної порції додає її підсумки до спільних атомарних лічильників, тож
+// атомарні операції виконуються раз на порцію, а не на кожну точку.
+// Канал і горутина, що його закриває, не потрібні.
+func aggregateAtomic(ctx context.Context, newKernel kernelFactory, parts []int, o options) workerResult {
+	var total atomicResult
+	var wg sync.WaitGroup
+
+	for i, pts := range parts {
+		wg.Add(1)
+		go func(index, pts int) {
+			defer wg.Done()
+			unpin := o.pinWorker(index)
+			defer unpin()
+			stop := o.timing.start()
+			worker(ctx, newKernel, index, pts, o, total.add)
+			stop()
+		}(i, pts)
+	}
+	wg.Wait()
+
+	return total.load()
+}
+
+// atomicResult — workerResult з атомарними лічильниками.
+type atomicResult struct {
+	inside, sampled, units, unitInside, unitSq atomic.Int64
+}
+
+// add атомарно додає підсумки w.
+func (a *atomicResult) add(w workerResult) {
+	a.inside.Add(w.inside)
+	a.sampled.Add(w.sampled)
+	a.units.Add(w.units)
+	a.unitInside.Add(w.unitInside)
+	a.unitSq.Add(w.unitSq)
+}
+
+// load повертає поточні значення лічильників.
+func (a *atomicResult) load() workerResult {
+	return workerResult{
+		inside:     a.inside.Load(),
+		sampled:    a.sampled.Load(),
+		units:      a.units.Load(),
+		unitInside: a.unitInside.Load(),
+		unitSq:     a.unitSq.Load(),
+	}
+}
@@ -0,0 +1,345 @@
+package montecarlo
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestEstimatePiAccuracy перевіряє, що паралельна оцінка з фіксованим зерном
+// близька до math.Pi. Допуск 0.01 приблизно у 20 разів більший за
+// стандартну похибку для 10^7 точок.
+func TestEstimatePiAccuracy(t *testing.T) {
+	res, err := EstimatePi(10_000_000, 4, WithSeed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := math.Abs(res.Pi - math.Pi); d > 0.01 {
+		t.Errorf("Pi = %v, off by %v from math.Pi", res.Pi, d)
+	}
+	if res.Total != 10_000_000 {
+		t.Errorf("Total = %d, want 10000000", res.Total)
+	}
+}
+
+// TestParallelMatchesSequential перевіряє, що один воркер генерує ті самі
+// точки, що й послідовне обчислення з тим самим зерном.
+func TestParallelMatchesSequential(t *testing.T) {
+	for _, seed := range []int64{0, 1, 42} {
+		seq := EstimatePiSequential(1_000_003, WithSeed(seed))
+		par, err := EstimatePi(1_000_003, 1, WithSeed(seed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seq.Inside != par.Inside || seq.Total != par.Total || seq.Pi != par.Pi {
+			t.Errorf("seed %d: sequential %d/%d (%v), parallel %d/%d (%v)",
+				seed, seq.Inside, seq.Total, seq.Pi, par.Inside, par.Total, par.Pi)
+		}
+	}
+}
+
+// TestSplitPoints перевіряє, що частини воркерів у сумі дають рівно totalPoints
+// і відрізняються не більше ніж на одну точку.
+func TestSplitPoints(t *testing.T) {
+	totals := []int{1, 2, 3, 7, 63, 64, 65, 1000, 999_999, 1_000_000, 1_000_001, 1<<30 + 1}
+	threads := []int{1, 2, 3, 4, 7, 8, 16, 63, 64, 65, 1000}
+	for _, total := range totals {
+		for _, n := range threads {
+			parts := splitPoints(total, n)
+			if want := min(n, total); len(parts) != want {
+				t.Errorf("splitPoints(%d, %d): %d parts, want %d", total, n, len(parts), want)
+			}
+			sum, lo, hi := 0, parts[0], parts[0]
+			for _, p := range parts {
+				sum += p
+				lo, hi = min(lo, p), max(hi, p)
+			}
+			if sum != total {
+				t.Errorf("splitPoints(%d, %d): parts sum to %d", total, n, sum)
+			}
+			if hi-lo > 1 {
+				t.Errorf("splitPoints(%d, %d): parts range from %d to %d", total, n, lo, hi)
+			}
+		}
+	}
+}
+
+// aggregationModes — набори налаштувань для кожного способу збору результатів.
+var aggregationModes = []struct {
+	name string
+	opts []Option
+}{
+	{"channel", nil},
+	{"atomic", []Option{WithAggregation(AggregateAtomic)}},
+	{"chunked", []Option{WithChunkSize(1000)}},
+	{"dynamic", []Option{WithDynamicScheduling(true), WithChunkSize(1000)}},
+}
+
+// TestParallelPiNoRace запускає кожен спосіб збору результатів з великою
+// кількістю потоків, малими порціями та колбеком прогресу, тож воркери
+// одночасно звертаються до всього спільного стану. Сам по собі тест перевіряє
+// лише лічильники; гонки він виявляє під детектором:
+//
+//	go test -race ./montecarlo -run NoRace
+//
+// Зміни спільного стану воркерів слід перевіряти саме так.
+func TestParallelPiNoRace(t *testing.T) {
+	const points = 200_000
+	for _, mode := range aggregationModes {
+		for _, threads := range []int{64, 256} {
+			var progress atomic.Int64
+			opts := append([]Option{WithSeed(1), WithBatchSize(500), WithProgress(func(done, total int) {
+				progress.Store(int64(done))
+			})}, mode.opts...)
+			res, err := EstimatePi(points, threads, opts...)
+			if err != nil {
+				t.Fatalf("%s/%d: %v", mode.name, threads, err)
+			}
+			if res.Total != points {
+				t.Errorf("%s/%d: Total = %d, want %d", mode.name, threads, res.Total, points)
+			}
+			if progress.Load() == 0 {
+				t.Errorf("%s/%d: progress callback was not called", mode.name, threads)
+			}
+		}
+	}
+}
+
+// TestConcurrentEstimates запускає кілька EstimatePi одночасно з різними
+// налаштуваннями і перевіряє, що кожна оцінка залишається в межах кількох
+// стандартних похибок від math.Pi, тобто виклики не впливають один на одного.
+func TestConcurrentEstimates(t *testing.T) {
+	const points = 2_000_000
+	results := make([]PiResult, 8)
+	errs := make([]error, len(results))
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			opts := append([]Option{WithSeed(int64(i))}, aggregationModes[i%len(aggregationModes)].opts...)
+			results[i], errs[i] = EstimatePi(points, 4, opts...)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, res := range results {
+		if errs[i] != nil {
+			t.Fatalf("call %d: %v", i, errs[i])
+		}
+		if res.Total != points {
+			t.Errorf("call %d: Total = %d, want %d", i, res.Total, points)
+		}
+		if d := math.Abs(res.Pi - math.Pi); d > 5*res.StdErr {
+			t.Errorf("call %d: Pi = %v, off by %.1f standard errors", i, res.Pi, d/res.StdErr)
+		}
+	}
+}
+
+// TestSeedReproducible перевіряє, що та сама конфігурація з тим самим зерном
+// двічі дає однакові лічильники. Між різними кількостями потоків результат
+// може відрізнятися, бо розбиття точок інше (див. WithSeed).
+func TestSeedReproducible(t *testing.T) {
+	for _, mode := range aggregationModes {
+		for _, threads := range []int{1, 2, 4, 7} {
+			opts := append([]Option{WithSeed(42)}, mode.opts...)
+			first, err := EstimatePi(100_003, threads, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := EstimatePi(100_003, threads, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if first.Inside != second.Inside || first.Total != second.Total {
+				t.Errorf("%s/%d: %d/%d, then %d/%d", mode.name, threads,
+					first.Inside, first.Total, second.Inside, second.Total)
+			}
+		}
+	}
+}
+
+// waitGoroutines чекає до секунди, доки кількість горутин не повернеться
+// до baseline: горутини, що закривають канали, можуть завершитися трохи пізніше
+// за повернення результату. Якщо не повернулася, тест провалюється.
+func waitGoroutines(t *testing.T, baseline int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines still running, want at most %d", runtime.NumGoroutine(), baseline)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestEstimatePiTerminates багато разів запускає кожен спосіб збору результатів
+// з різною кількістю потоків, зокрема більшою за кількість точок, і перевіряє,
+// що кожен виклик завершується вчасно, а після всіх викликів не залишається горутин.
+// Так виявляються подвійне закриття каналу (паніка), відсутнє закриття (зависання)
+// та горутини, що не завершилися.
+func TestEstimatePiTerminates(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	for _, mode := range aggregationModes {
+		for i := 0; i < 200; i++ {
+			threads := 1 + i%70
+			points := 1 + i*37
+			done := make(chan error, 1)
+			go func() {
+				res, err := EstimatePi(points, threads, append([]Option{WithSeed(int64(i))}, mode.opts...)...)
+				if err == nil && res.Total != int64(points) {
+					err = fmt.Errorf("Total = %d, want %d", res.Total, points)
+				}
+				done <- err
+			}()
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Fatalf("%s, %d points, %d threads: %v", mode.name, points, threads, err)
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatalf("%s, %d points, %d threads: did not finish in 10s", mode.name, points, threads)
+			}
+		}
+	}
+	waitGoroutines(t, baseline)
+}
+
+// TestConvergence перевіряє основну властивість методу: середня за кількома
+// зернами абсолютна похибка при 10^7 точках менша, ніж при 10^4. Похибка спадає
+// як 1/sqrt(N), тож очікуване відношення — близько 30; вимагається лише 5,
+// щоб окремі невдалі зерна не робили тест хитким.
+func TestConvergence(t *testing.T) {
+	meanError := func(points int) float64 {
+		const seeds = 8
+		sum := 0.0
+		for seed := int64(1); seed <= seeds; seed++ {
+			res, err := EstimatePi(points, 4, WithSeed(seed))
+			if err != nil {
+				t.Fatal(err)
+			}
+			sum += math.Abs(res.Pi - math.Pi)
+		}
+		return sum / seeds
+	}
+	small, large := meanError(10_000), meanError(10_000_000)
+	if large*5 > small {
+		t.Errorf("mean |error|: %v at 10^4 points, %v at 10^7 points", small, large)
+	}
+}
+
+// TestUnbiased перевіряє відсутність систематичного зміщення: середнє 1000
+// коротких оцінок з різними зернами має відрізнятися від math.Pi не більше
+// ніж на 4 стандартні похибки середнього. Зміщення через межу <= 1.0 чи
+// збіг зерен воркерів проявилося б як стале відхилення середнього.
+func TestUnbiased(t *testing.T) {
+	const runs, points = 1000, 10_000
+	var sum, sumSq float64
+	for seed := int64(0); seed < runs; seed++ {
+		res, err := EstimatePi(points, 2, WithSeed(seed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum += res.Pi
+		sumSq += res.Pi * res.Pi
+	}
+	mean := sum / runs
+	sem := math.Sqrt((sumSq/runs - mean*mean) / (runs - 1))
+	if d := math.Abs(mean - math.Pi); d > 4*sem {
+		t.Errorf("mean of %d estimates = %v, off by %.2f standard errors of the mean (SEM %v)", runs, mean, d/sem, sem)
+	}
+}
+
+// TestGOMAXPROCSUnchanged перевіряє, що жоден спосіб обчислення не змінює
+// глобальне значення GOMAXPROCS. Перед викликами встановлюється значення,
+// відмінне від кількості процесорів, щоб помітити і його скидання до NumCPU.
+func TestGOMAXPROCSUnchanged(t *testing.T) {
+	const procs = 3
+	old := runtime.GOMAXPROCS(procs)
+	t.Cleanup(func() { runtime.GOMAXPROCS(old) })
+	check := func(name string) {
+		t.Helper()
+		if got := runtime.GOMAXPROCS(0); got != procs {
+			t.Errorf("GOMAXPROCS = %d after %s, want %d", got, name, procs)
+		}
+	}
+
+	if _, err := EstimatePi(100_000, 8, WithSeed(1)); err != nil {
+		t.Fatal(err)
+	}
+	check("EstimatePi")
+
+	pool := NewPool(8)
+	_, err := pool.EstimatePi(100_000, WithSeed(1))
+	pool.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("Pool.EstimatePi")
+
+	acc := NewAccumulator(8, WithSeed(1))
+	err = acc.Add(100_000)
+	acc.Stop()
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("Accumulator")
+
+	if _, err := EstimatePiForDuration(20*time.Millisecond, 8, WithSeed(1)); err != nil {
+		t.Fatal(err)
+	}
+	check("EstimatePiForDuration")
+}
+
+// TestDynamicPointAccounting перевіряє динамічний розподіл при розмірах порцій,
+// що не ділять кількість точок націло: оброблено рівно totalPoints точок,
+// а кожну порцію захоплено рівно один раз. Генератор створюється окремо для кожної
+// порції за її індексом, тож фабрика генераторів фіксує, які порції захоплено.
+func TestDynamicPointAccounting(t *testing.T) {
+	tests := []struct{ totalPoints, chunkSize, threads int }{
+		{1, 1, 1},
+		{1, 100, 8},
+		{99, 100, 4},
+		{100, 100, 4},
+		{101, 100, 4},
+		{1000, 7, 3},
+		{1000, 7, 64},
+		{12_345, 1000, 5},
+		{100_000, 1, 16},
+		{1_000_003, 4096, 32},
+		{1_000_003, 999_999, 2},
+	}
+	for _, tt := range tests {
+		var mu sync.Mutex
+		claims := make(map[int]int)
+		factory := func(index int) *rand.Rand {
+			mu.Lock()
+			claims[index]++
+			mu.Unlock()
+			return rand.New(rand.NewPCG(1, uint64(index)))
+		}
+
+		res, err := EstimatePi(tt.totalPoints, tt.threads,
+			WithDynamicScheduling(true), WithChunkSize(tt.chunkSize), WithRandFactory(factory))
+		if err != nil {
+			t.Fatalf("%+v: %v", tt, err)
+		}
+		if res.Total != int64(tt.totalPoints) {
+			t.Errorf("%+v: Total = %d", tt, res.Total)
+		}
+		numChunks := chunkCount(tt.totalPoints, tt.chunkSize)
+		if len(claims) != numChunks {
+			t.Errorf("%+v: %d chunks claimed, want %d", tt, len(claims), numChunks)
+		}
+		for i := 0; i < numChunks; i++ {
+			if claims[i] != 1 {
+				t.Errorf("%+v: chunk %d claimed %d times", tt, i, claims[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package montecarlo
+
+import (
+	"log/slog"
+	"math/rand/v2"
+	"time"
+)
+
+// Aggregation визначає спосіб збору результатів воркерів.
+type Aggregation int
+
+const (
+	// AggregateChannel збирає результати через буферизований канал (за замовчуванням).
+	AggregateChannel Aggregation = iota
+	// AggregateAtomic додає результати воркерів до спільних atomic.Int64
+	// без каналу та додаткової горутини. Має менші накладні витрати,
+	// коли на кожного воркера припадає мало точок.
+	AggregateAtomic
+)
+
+// Option налаштовує параметри обчислення.
+type Option func(*options)
+
+// options містить налаштування, зібрані з переданих Option.
+type options struct {
+	seed    int64 // Базове зерно генератора
+	seedSet bool  // Чи було зерно задано явно
+
+	firstWorker int // Зсув індексів воркерів, щоб серії запусків не повторювали зерна
+
+	aggregation Aggregation // Спосіб збору результатів
+	batchSize   int         // Кількість точок у порції воркера
+	chunkSize   int         // Кількість точок в одному завданні; 0 — рівний поділ
+	dynamic     bool        // Динамічний розподіл порцій через атомарний лічильник
+
+	randFactory func(workerIndex int) *rand.Rand // Фабрика генераторів воркерів
+	packed      bool                             // Обидві координати з одного Uint64
+	float32     bool                             // Координати та арифметика float32
+	antithetic  bool                             // Антитетичні пари точок
+	gridN       int                              // Розмір сітки стратифікованої вибірки; 0 — вимкнено
+	quasi       bool                             // Квазівипадкова послідовність Гальтона
+
+	cpuAffinity  bool    // Прив'язка воркерів до логічних процесорів
+	throttleRate float64 // Обмеження швидкості воркера, точок за секунду; 0 — вимкнено
+
+	progressFn func(done, total int) // Колбек прогресу, заданий користувачем
+	progress   *progressTracker      // Трекер прогресу поточного обчислення
+
+	timingFn func(WorkerTiming) // Колбек статистики часу воркерів
+	timing   *timingTracker     // Трекер часу воркерів поточного обчислення
+
+	histogramSize int                 // Розмір сітки гістограми точок
+	histogramFn   func(cells [][]int) // Колбек гістограми, заданий користувачем
+	histogram     *histogramTracker   // Трекер гістограми; спільний для всіх обчислень з цими налаштуваннями
+
+	snapshotCh    chan<- PiResult  // Канал знімків оцінки
+	snapshotEvery time.Duration    // Період знімків
+	snapshots     *snapshotTracker // Трекер знімків поточного обчислення
+
+	logger *slog.Logger // Логер діагностичних повідомлень
+}
+
+// WithSeed задає базове зерно генератора випадкових чисел.
+// Генератор кожного воркера детерміновано виводиться з нього та індексу воркера,
+// тому результати при однакових параметрах відтворювані.
+//
+// Відтворюваність гарантується лише при тій самій кількості потоків:
+// від неї залежить розбиття точок, тож, наприклад, при 2 потоках воркер 0
+// генерує першу половину точок свого потоку, а при 4 — лише першу чверть,
+// і воркери 2 і 3 використовують потоки, яких при 2 потоках немає.
+// Обидва результати однаково коректні, але кількість влучень різна.
+// Щоб результат не залежав від кількості потоків, використовуйте WithChunkSize.
+func WithSeed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+		o.seedSet = true
+	}
+}
+
+// WithAggregation задає спосіб збору результатів воркерів.
+func WithAggregation(a Aggregation) Option {
+	return func(o *options) {
+		o.aggregation = a
+	}
+}
+
+// WithProgress задає колбек, який воркери викликають після кожної порції точок
+// (див. WithBatchSize) з кількістю вже оброблених точок done із загальних total.
+// Колбек викликається з різних горутин одночасно, тому має бути потокобезпечним.
+// Значення done підсумовується атомарно, але виклики з різних горутин
+// можуть надходити не по порядку.
+// Послідовне обчислення колбек не викликає.
+func WithProgress(fn func(done, total int)) Option {
+	return func(o *options) {
+		o.progressFn = fn
+	}
+}
+
+// WithRandFactory задає фабрику, що створює генератор випадкових чисел для кожного воркера.
+// Фабрика викликається один раз на воркера з його горутини, тому має бути
+// безпечною для конкурентних викликів, а кожен повернений *rand.Rand має
+// використовуватися лише одним воркером. При заданій фабриці WithSeed ігнорується.
+// Без фабрики кожен воркер отримує rand.New(rand.NewPCG(seed, індекс)).
+func WithRandFactory(factory func(workerIndex int) *rand.Rand) Option {
+	return func(o *options) {
+		o.randFactory = factory
+	}
+}
+
+// WithPackedCoordinates вмикає генерацію обох координат точки з одного
+// виклику Uint64 замість двох викликів Float64. У вимірюваннях це приблизно
+// вдвічі пришвидшує внутрішній цикл, ціною меншої точності координат
+// (31 біт замість 53), що не впливає на оцінку в межах похибки методу.
+// Застосовується до паралельних обчислень PI та Integrate; результати
+// при фіксованому зерні відрізняються від звичайного режиму.
+func WithPackedCoordinates(enabled bool) Option {
+	return func(o *options) {
+		o.packed = enabled
+	}
+}
+
+// WithFloat32 вмикає координати та обчислення перевірки влучення у float32
+// замість float64. Координати мають 24 біти точності замість 53, а похибка
+// округлення x*x+y*y біля межі кола зміщує оцінку PI приблизно на 10^-7,
+// що менше за статистичну похибку методу аж до ~10^14 точок.
+// Генератор викликається так само двічі на точку, тож виграш можливий лише
+// від дешевшої арифметики, а не від меншої кількості звернень до генератора.
+// Застосовується лише до паралельних обчислень PI зі звичайною вибіркою:
+// WithHistogram, WithStratified, WithQuasiRandom, WithAntithetic і
+// WithPackedCoordinates мають пріоритет, а Integrate та EstimateArea працюють з float64.
+func WithFloat32(enabled bool) Option {
+	return func(o *options) {
+		o.float32 = enabled
+	}
+}
+
+// WithAntithetic вмикає антитетичну вибірку: до кожної випадкової точки (x, y)
+// додається її відображення (1-x, 1-y). Індикатор влучення в чверть кола
+// спадає за обома координатами, тож влучення точки та її відображення
+// від'ємно корельовані, і дисперсія оцінки при тій самій кількості точок
+// зменшується: для PI дисперсія падає приблизно на чверть, а стандартна
+// похибка — приблизно на 15%. Стандартна похибка в PiResult оцінюється за парами точок.
+// Застосовується до паралельних обчислень PI та Integrate.
+func WithAntithetic(enabled bool) Option {
+	return func(o *options) {
+		o.antithetic = enabled
+	}
+}
+
+// WithStratified вмикає стратифіковану вибірку: одиничний квадрат ділиться на
+// сітку gridN×gridN рівних клітинок, і точки генеруються по одній у кожній
+// клітинці по черзі, тож кожна клітинка отримує пропорційну частку точок.
+// Це прибирає розкид кількості точок між частинами квадрата, і влучення
+// випадкові лише в клітинках, які перетинає межа області, тому стандартна
+// похибка при тій самій кількості точок помітно менша, ніж при звичайній вибірці.
+// Стандартна похибка в PiResult оцінюється за повними обходами сітки (gridN² точок),
+// тож кількість точок кожного воркера варто брати кратною gridN² і значно більшою за неї.
+// Значення менше за 1 вимикає режим. Має пріоритет над WithAntithetic.
+// Застосовується до паралельних обчислень PI та Integrate.
+func WithStratified(gridN int) Option {
+	return func(o *options) {
+		o.gridN = max(gridN, 0)
+	}
+}
+
+// WithQuasiRandom вмикає квазі-Монте-Карло: точки беруться з послідовності
+// Гальтона з основами 2 і 3, яка заповнює квадрат рівномірніше за випадкові
+// точки, тож похибка спадає майже як 1/N замість 1/sqrt(N). Генератор кожного
+// воркера лише обирає випадковий початок послідовності, тож оцінка залишається
+// випадковою і відтворюваною з WithSeed. Стандартна похибка в PiResult
+// обчислюється як для незалежних точок, тому завищує фактичну похибку.
+// Має пріоритет над WithAntithetic і WithPackedCoordinates; WithStratified
+// має пріоритет над нею. З WithHistogram гістограма будується за точками Гальтона.
+// Застосовується до паралельних обчислень PI та Integrate.
+func WithQuasiRandom(enabled bool) Option {
+	return func(o *options) {
+		o.quasi = enabled
+	}
+}
+
+// WithCPUAffinity вмикає прив'язку воркерів до логічних процесорів: кожен воркер
+// закріплює свою горутину за потоком ОС через runtime.LockOSThread і в Linux
+// обмежує цей потік одним процесором (sched_setaffinity), розподіляючи воркерів
+// по доступних процесорах по колу за порядковим номером горутини. З WithChunkSize
+// і WithDynamicScheduling горутина прив'язується один раз і обробляє всі свої
+// порції на тому самому процесорі, а індекс порції визначає лише генератор.
+// Після завершення воркера попередня маска відновлюється. Працює лише в Linux; на інших платформах потік закріплюється,
+// але прив'язка до процесора не виконується. Якщо змінити маску не вдалося,
+// воркер продовжує без прив'язки.
+func WithCPUAffinity(enabled bool) Option {
+	return func(o *options) {
+		o.cpuAffinity = enabled
+	}
+}
+
+// WithBatchSize задає кількість точок у порції воркера (за замовчуванням 65536).
+// Після кожної порції воркер передає її підсумки агрегатору (в режимі
+// AggregateAtomic — одним атомарним додаванням), оновлює прогрес і перевіряє
+// скасування контексту. Менші порції дають частіші оновлення ціною накладних витрат.
+// Значення менше за 1 означає розмір за замовчуванням.
+func WithBatchSize(k int) Option {
+	return func(o *options) {
+		o.batchSize = k
+	}
+}
+
+// WithChunkSize відв'язує розмір завдання від кількості потоків: точки діляться
+// на завдання по n точок, і воркери забирають їх з черги, доки точки не закінчаться.
+// Кожне завдання використовує генератор з власним індексом (зерно та індекс завдання).
+// Значення менше за 1 (за замовчуванням) означає рівний поділ точок між потоками.
+func WithChunkSize(n int) Option {
+	return func(o *options) {
+		o.chunkSize = n
+	}
+}
+
+// WithDynamicScheduling вмикає динамічний розподіл точок: замість поділу
+// на рівні частини воркери по черзі забирають порції зі спільного атомарного
+// лічильника, доки точки не закінчаться. Воркер, якого планувальник запустив
+// раніше або частіше, просто обробить більше порцій, тож на завантаженій машині
+// немає відсталих воркерів, на яких чекає все обчислення.
+// Розмір порції задає WithChunkSize (за замовчуванням 100000 точок).
+// Як і з WithChunkSize, генератор кожної порції визначається її індексом,
+// тож результат при фіксованому зерні не залежить від кількості потоків.
+// Не застосовується до Pool.
+func WithDynamicScheduling(enabled bool) Option {
+	return func(o *options) {
+		o.dynamic = enabled
+	}
+}
+
+// newOptions збирає налаштування з переданих Option.
+// Якщо зерно не задано явно, базове зерно один раз береться з поточного часу.
+// Без WithLogger використовується логер, що відкидає всі повідомлення.
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.seedSet {
+		o.seed = time.Now().UnixNano()
+	}
+	if o.batchSize < 1 {
+		o.batchSize = defaultBatchSize
+	}
+	o.histogram = newHistogramTracker(o.histogramSize, o.histogramFn)
+	if o.logger == nil {
+		o.logger = slog.New(discardHandler{})
+	}
+	return o
+}
+
+// newRand створює генератор для воркера з індексом workerIndex.
+// Базове зерно визначає послідовність, а індекс воркера — її потік PCG.
+// Індекси воркерів унікальні в межах одного виклику, тому їхні
+// послідовності не збігаються навіть при однаковій кількості точок.
+// Власний генератор кожного воркера, а не конкурентно безпечні функції
+// верхнього рівня math/rand/v2, потрібен для відтворюваності з WithSeed:
+// глобальний генератор v2 не можна засіяти.
+func (o options) newRand(workerIndex int) *rand.Rand {
+	if o.randFactory != nil {
+		return o.randFactory(o.firstWorker + workerIndex)
+	}
+	return rand.New(rand.NewPCG(uint64(o.seed), uint64(o.firstWorker+workerIndex)))
+}
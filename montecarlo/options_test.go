@@ -0,0 +1,23 @@
+package montecarlo
+
+import "testing"
+
+// TestNewRandDistinctStreams перевіряє, що воркери з однаковою кількістю точок
+// і тим самим базовим зерном отримують різні послідовності. Кількість влучень
+// двох незалежних воркерів може випадково збігтися, тож порівнюються самі
+// значення генераторів, а не лічильники.
+func TestNewRandDistinctStreams(t *testing.T) {
+	for _, o := range []options{newOptions(nil), newOptions([]Option{WithSeed(0)}), newOptions([]Option{WithSeed(42)})} {
+		r0, r1 := o.newRand(0), o.newRand(1)
+		same := 0
+		for i := 0; i < 1000; i++ {
+			if r0.Uint64() == r1.Uint64() {
+				same++
+			}
+		}
+		// Випадковий збіг 64-бітних значень практично неможливий
+		if same > 0 {
+			t.Errorf("seed %d: %d of 1000 values of workers 0 and 1 coincide", o.seed, same)
+		}
+	}
+}
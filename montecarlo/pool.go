@@ -0,0 +1,148 @@
+package montecarlo
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// poolJob — завдання для воркера пулу.
+type poolJob struct {
+	ctx    context.Context
+	kernel kernelFactory
+	index  int
+	points int
+	o      options
+	result chan<- workerResult
+}
+
+// Pool утримує фіксований набір довготривалих горутин-воркерів,
+// які отримують завдання через канал. На відміну від EstimatePi,
+// горутини не створюються заново при кожному обчисленні.
+// Pool безпечно використовувати з кількох горутин одночасно.
+type Pool struct {
+	size int
+	jobs chan poolJob
+	wg   sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewPool створює пул з size воркерів. Значення size менше за 1 вважається рівним 1.
+// Після використання пул потрібно закрити через Close.
+func NewPool(size int) *Pool {
+	if size < 1 {
+		size = 1
+	}
+	p := &Pool{
+		size: size,
+		jobs: make(chan poolJob, size),
+	}
+	for i := 0; i < size; i++ {
+		p.wg.Add(1)
+		go p.run(i)
+	}
+	return p
+}
+
+// run обробляє завдання, доки канал завдань не буде закрито.
+// Ordinal — порядковий номер воркера пулу, за яким з WithCPUAffinity
+// обирається процесор, тож воркер працює на тому самому процесорі
+// незалежно від індексу завдання.
+func (p *Pool) run(ordinal int) {
+	defer p.wg.Done()
+	for job := range p.jobs {
+		unpin := job.o.pinWorker(ordinal)
+		stop := job.o.timing.start()
+		var local workerResult
+		worker(job.ctx, job.kernel, job.index, job.points, job.o, local.add)
+		stop()
+		unpin()
+		job.result <- local
+	}
+}
+
+// Size повертає кількість воркерів пулу.
+func (p *Pool) Size() int {
+	return p.size
+}
+
+// EstimatePi обчислює PI, розподіляючи totalPoints точок між воркерами пулу.
+// За замовчуванням точки діляться порівну між воркерами; з WithChunkSize
+// кожне завдання містить задану кількість точок, і воркери забирають
+// завдання з черги, доки точки не закінчаться.
+// Повертає помилку, якщо кількість точок менша за 1 або пул уже закрито.
+func (p *Pool) EstimatePi(totalPoints int, opts ...Option) (PiResult, error) {
+	if err := validatePoints(totalPoints); err != nil {
+		return PiResult{}, err
+	}
+
+	o := newOptions(opts)
+	o.progress = newProgressTracker(o.progressFn, totalPoints)
+	o.timing = newTimingTracker(o.timingFn)
+	o.histogram.plan(p.size)
+	o.snapshots = newSnapshotTracker(o.snapshotCh, o.snapshotEvery, o.unitSize(), p.size)
+	newKernel := o.circleKernel()
+
+	var numJobs int
+	var jobPoints func(i int) int
+	if o.chunkSize > 0 {
+		numJobs = chunkCount(totalPoints, o.chunkSize)
+		jobPoints = func(i int) int { return chunkPoints(i, totalPoints, o.chunkSize) }
+	} else {
+		parts := splitPoints(totalPoints, p.size)
+		numJobs = len(parts)
+		jobPoints = func(i int) int { return parts[i] }
+	}
+
+	// Блокування не дає закрити канал завдань, поки вони надсилаються
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return PiResult{}, errors.New("pool is closed")
+	}
+
+	// Результати збираються паралельно з надсиланням завдань,
+	// тож кількість завдань може перевищувати розмір буферів
+	resultChan := make(chan workerResult, p.size)
+	totalChan := make(chan workerResult, 1)
+	go func() {
+		var total workerResult
+		for i := 0; i < numJobs; i++ {
+			total.add(<-resultChan)
+		}
+		totalChan <- total
+	}()
+
+	o.logger.Debug("pool estimation started", "points", totalPoints, "workers", p.size, "jobs", numJobs, "seed", o.seed)
+	stopSnapshots := o.snapshots.start()
+	for i := 0; i < numJobs; i++ {
+		p.jobs <- poolJob{ctx: context.Background(), kernel: newKernel, index: i, points: jobPoints(i), o: o, result: resultChan}
+	}
+	p.mu.RUnlock()
+
+	total := <-totalChan
+	stopSnapshots()
+	o.timing.report()
+	o.histogram.report()
+	o.logger.Debug("pool estimation finished", "inside", total.inside, "sampled", total.sampled)
+	res := total.piResult(o.unitSize())
+	res.Threads = p.size
+	return res, nil
+}
+
+// Close зупиняє воркерів пулу та чекає на їх завершення.
+// Повторні виклики нічого не роблять.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.jobs)
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
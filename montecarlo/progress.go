@@ -0,0 +1,26 @@
+package montecarlo
+
+import "sync/atomic"
+
+// progressTracker підсумовує прогрес усіх воркерів одного обчислення.
+type progressTracker struct {
+	fn    func(done, total int)
+	total int
+	done  atomic.Int64
+}
+
+// newProgressTracker створює трекер або повертає nil, якщо колбек не задано.
+func newProgressTracker(fn func(done, total int), total int) *progressTracker {
+	if fn == nil {
+		return nil
+	}
+	return &progressTracker{fn: fn, total: total}
+}
+
+// add додає n оброблених точок і викликає колбек. Безпечний для nil-трекера.
+func (p *progressTracker) add(n int) {
+	if p == nil || n == 0 {
+		return
+	}
+	p.fn(int(p.done.Add(int64(n))), p.total)
+}
@@ -0,0 +1,82 @@
+package montecarlo
+
+import (
+	"math/bits"
+	"math/rand/v2"
+)
+
+// haltonMaxStart обмежує випадковий початок послідовності Гальтона,
+// щоб обчислення radicalInverse3 лишалися швидкими.
+const haltonMaxStart = 1 << 40
+
+// halton генерує двовимірну послідовність Гальтона з основами 2 і 3.
+type halton struct {
+	index uint64 // Номер наступної точки послідовності
+}
+
+// newHalton створює послідовність з випадковим початком, обраним r.
+// Будь-який відрізок послідовності Гальтона рівномірно заповнює квадрат,
+// тож воркери з різними початками дають незалежні оцінки з низькою розбіжністю.
+func newHalton(r *rand.Rand) *halton {
+	return &halton{index: 1 + r.Uint64N(haltonMaxStart)}
+}
+
+// next повертає наступну точку послідовності.
+func (h *halton) next() (x, y float64) {
+	i := h.index
+	h.index++
+	return radicalInverse2(i), radicalInverse3(i)
+}
+
+// radicalInverse2 дзеркально відображає двійкові цифри i відносно коми.
+func radicalInverse2(i uint64) float64 {
+	return float64(bits.Reverse64(i)>>11) * 0x1p-53
+}
+
+// radical3Digits — кількість трійкових цифр, що обробляються одним звертанням до таблиці.
+const radical3Digits = 10
+
+// radical3Block = 3^radical3Digits — розмір таблиці radical3Table.
+const radical3Block = 59049
+
+// radical3Table[i] — radicalInverse3 для i < radical3Block, обчислене цифра за цифрою.
+var radical3Table = func() []float64 {
+	table := make([]float64, radical3Block)
+	for i := range table {
+		x, f := 0.0, 1.0/3
+		for v := i; v > 0; v /= 3 {
+			x += float64(v%3) * f
+			f /= 3
+		}
+		table[i] = x
+	}
+	return table
+}()
+
+// radicalInverse3 дзеркально відображає трійкові цифри i відносно коми.
+// Цифри обробляються блоками по radical3Digits через radical3Table:
+// кожен наступний блок молодших цифр зсувається на 3^-radical3Digits.
+func radicalInverse3(i uint64) float64 {
+	x, scale := 0.0, 1.0
+	for i > 0 {
+		x += radical3Table[i%radical3Block] * scale
+		i /= radical3Block
+		scale /= radical3Block
+	}
+	return x
+}
+
+// quasiKernel створює kernelFactory, що бере точки з послідовності Гальтона
+// замість генератора; генератор воркера лише обирає початок послідовності.
+func quasiKernel(region Region) kernelFactory {
+	return func(r *rand.Rand) kernel {
+		seq := newHalton(r)
+		return func(n int) workerResult {
+			inside := 0
+			for i := 0; i < n; i++ {
+				inside += boolToInt(region(seq.next()))
+			}
+			return pointResult(n, inside)
+		}
+	}
+}
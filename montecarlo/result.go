@@ -0,0 +1,146 @@
+package montecarlo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// PiResult містить результат оцінки PI.
+type PiResult struct {
+	Pi      float64       // Оцінка числа PI
+	StdErr  float64       // Стандартна похибка оцінки
+	Elapsed time.Duration // Час обчислення; заповнюється лише Timed та EstimatePiForDuration
+
+	// Throughput — пропускна здатність у точках за секунду; заповнюється лише Timed та EstimatePiForDuration
+	Throughput float64
+
+	Inside int64 // Кількість точок, що потрапили в коло
+	Total  int64 // Кількість фактично згенерованих точок
+
+	Threads int // Кількість потоків обчислення; 0 — невідома, наприклад після Combine
+}
+
+// String повертає підсумок результату одним рядком, наприклад
+// "Pi=3.141593 ±0.0013 in 12.3ms (4 threads, 1000000 points, 8.13e+07 points/s)".
+// Час і пропускна здатність виводяться, лише якщо їх заповнено (див. Timed),
+// а кількість потоків — якщо вона відома. Нескінченна чи невизначена оцінка
+// позначається явно як "ERROR: non-finite estimate".
+func (r PiResult) String() string {
+	var sb strings.Builder
+	if isFinite(r.Pi) && isFinite(r.StdErr) {
+		fmt.Fprintf(&sb, "Pi=%.6f ±%.4f", r.Pi, r.StdErr)
+	} else {
+		fmt.Fprintf(&sb, "Pi=%v ±%v ERROR: non-finite estimate", r.Pi, r.StdErr)
+	}
+	if r.Elapsed > 0 {
+		fmt.Fprintf(&sb, " in %s", r.Elapsed.Round(100*time.Microsecond))
+	}
+	sb.WriteString(" (")
+	switch {
+	case r.Threads == 1:
+		sb.WriteString("1 thread, ")
+	case r.Threads > 1:
+		fmt.Fprintf(&sb, "%d threads, ", r.Threads)
+	}
+	fmt.Fprintf(&sb, "%d points", r.Total)
+	if r.Throughput > 0 {
+		fmt.Fprintf(&sb, ", %.3g points/s", r.Throughput)
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+// Timed виконує f і записує тривалість його виконання в поле Elapsed результату,
+// а також пропускну здатність у Throughput.
+// Самі функції оцінки час не вимірюють, тож вимірювання потрібне лише там, де воно має сенс.
+func Timed(f func() (PiResult, error)) (PiResult, error) {
+	start := time.Now()
+	res, err := f()
+	res.Elapsed = time.Since(start)
+	if res.Elapsed > 0 {
+		res.Throughput = float64(res.Total) / res.Elapsed.Seconds()
+	}
+	return res, err
+}
+
+// piFromCounts повертає оцінку PI ≈ 4 * inside / total, або 0, якщо точок немає.
+// Усі шляхи обчислення переводять кількість влучень в оцінку PI лише через цю функцію.
+// Лічильники мають тип int64 і не переповнюються до 2^63-1 точок навіть
+// на 32-бітних платформах. Перетворення у float64 точне до 2^53 ≈ 9·10^15 точок;
+// понад цю межу відносна похибка округлення не перевищує 2^-53, що на багато
+// порядків менше за статистичну похибку методу ≈ 1.6/sqrt(total).
+func piFromCounts(inside, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return 4.0 * float64(inside) / float64(total)
+}
+
+// stdErrFromCounts повертає стандартну похибку оцінки PI з біноміальної дисперсії:
+// з p = inside/total вона дорівнює 4*sqrt(p*(1-p)/total).
+// Якщо всі точки влучили або всі промахнулися, ця формула дає 0, що хибно
+// вказує на точну оцінку; тоді p замінюється на (inside+1)/(total+2)
+// (правило Лапласа), що дає ненульову консервативну похибку.
+func stdErrFromCounts(inside, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	p := float64(inside) / float64(total)
+	if inside == 0 || inside == total {
+		p = float64(inside+1) / float64(total+2)
+	}
+	return 4.0 * math.Sqrt(p*(1-p)/float64(total))
+}
+
+// newPiResult обчислює оцінку PI та її біноміальну стандартну похибку за кількістю точок.
+func newPiResult(inside, total int64) PiResult {
+	return PiResult{
+		Pi:     piFromCounts(inside, total),
+		StdErr: stdErrFromCounts(inside, total),
+		Inside: inside,
+		Total:  total,
+	}
+}
+
+// piResult обчислює оцінку PI за підсумками воркерів, де кожне випробування
+// містить unitSize точок. Стандартна похибка оцінюється за вибірковою
+// дисперсією завершених випробувань, що враховує залежність точок усередині
+// випробування (наприклад, в антитетичному режимі). Для одноточкових
+// випробувань це збігається з біноміальною формулою newPiResult.
+// Якщо всі випробування дали однаковий результат і дисперсія нульова,
+// залишається консервативна біноміальна похибка з newPiResult.
+func (w workerResult) piResult(unitSize int) PiResult {
+	res := newPiResult(w.inside, w.sampled)
+	if w.units > 0 && unitSize > 0 {
+		n := float64(w.units)
+		size := float64(unitSize)
+		mean := float64(w.unitInside) / n / size
+		meanSq := float64(w.unitSq) / n / (size * size)
+		// Дисперсія середнього по точках: кожне випробування дає size точок
+		if variance := meanSq - mean*mean; variance > 0 {
+			res.StdErr = 4.0 * math.Sqrt(variance/n)
+		}
+	}
+	return res
+}
+
+// Combine об'єднує результати незалежних запусків, підсумовуючи Inside та Total,
+// і заново обчислює PI та біноміальну стандартну похибку за сумарними лічильниками.
+// Оскільки результати методу Монте-Карло адитивні, об'єднання двох половин
+// еквівалентне одному запуску з сумарною кількістю точок.
+// Elapsed, Throughput та Threads не об'єднуються і залишаються нульовими.
+func Combine(results ...PiResult) PiResult {
+	var inside, total int64
+	for _, res := range results {
+		inside += res.Inside
+		total += res.Total
+	}
+	return newPiResult(inside, total)
+}
+
+// isFinite повідомляє, чи є v скінченним числом, а не NaN чи ±Inf.
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
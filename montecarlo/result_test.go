@@ -0,0 +1,49 @@
+package montecarlo
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// TestCombineLargeCounts об'єднує результати із сумарно 10^18 точок, що
+// далеко за межею точного подання в float64 (2^53), і перевіряє, що лічильники
+// не переповнюються, а оцінка PI відрізняється від точного 4·inside/total
+// не більше ніж на кілька одиниць округлення.
+func TestCombineLargeCounts(t *testing.T) {
+	const parts = 4
+	const partTotal = 250_000_000_000_000_000
+	var partInside int64 = 196_349_540_849_362_077 // ≈ partTotal·π/4
+
+	results := make([]PiResult, parts)
+	for i := range results {
+		results[i] = newPiResult(partInside+int64(i), partTotal)
+	}
+	res := Combine(results...)
+
+	wantInside := int64(parts)*partInside + 6
+	if res.Total != parts*partTotal || res.Inside != wantInside {
+		t.Fatalf("Combine: %d/%d, want %d/%d", res.Inside, res.Total, wantInside, int64(parts*partTotal))
+	}
+
+	exact, _ := new(big.Rat).SetFrac(big.NewInt(4*res.Inside), big.NewInt(res.Total)).Float64()
+	if rel := math.Abs(res.Pi-exact) / exact; rel > 4*math.Pow(2, -53) {
+		t.Errorf("Pi = %.17g, exact ratio %.17g, relative error %g", res.Pi, exact, rel)
+	}
+	if d := math.Abs(res.Pi - math.Pi); d > 1e-15 {
+		t.Errorf("Pi = %.17g, off by %g from math.Pi", res.Pi, d)
+	}
+	if res.StdErr <= 0 || res.StdErr > 1e-8 {
+		t.Errorf("StdErr = %g, want a small positive value", res.StdErr)
+	}
+}
+
+// TestPiFromCountsMaxInt64 перевіряє оцінку при лічильниках біля межі int64.
+func TestPiFromCountsMaxInt64(t *testing.T) {
+	if got := piFromCounts(math.MaxInt64, math.MaxInt64); got != 4 {
+		t.Errorf("piFromCounts(MaxInt64, MaxInt64) = %v, want 4", got)
+	}
+	if got := piFromCounts(math.MaxInt64/2, math.MaxInt64); math.Abs(got-2) > 1e-15 {
+		t.Errorf("piFromCounts(MaxInt64/2, MaxInt64) = %v, want 2", got)
+	}
+}
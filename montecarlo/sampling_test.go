@@ -0,0 +1,20 @@
+package montecarlo
+
+import (
+	"math"
+	"testing"
+)
+
+// TestPackedCoordinatesAccuracy перевіряє, що менша точність координат
+// WithPackedCoordinates не зміщує оцінку за межі статистичної похибки.
+func TestPackedCoordinatesAccuracy(t *testing.T) {
+	for _, seed := range []int64{1, 2, 3} {
+		res, err := EstimatePi(10_000_000, 4, WithSeed(seed), WithPackedCoordinates(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := math.Abs(res.Pi - math.Pi); d > 5*res.StdErr {
+			t.Errorf("seed %d: Pi = %v, off by %v (%.1f standard errors)", seed, res.Pi, d, d/res.StdErr)
+		}
+	}
+}
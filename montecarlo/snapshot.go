@@ -0,0 +1,87 @@
+package montecarlo
+
+import (
+	"sync"
+	"time"
+)
+
+// WithSnapshots задає канал, у який під час обчислення кожні every надсилається
+// поточна оцінка PI за всіма вже обробленими порціями (див. WithBatchSize),
+// а після завершення — підсумкова. Надсилання неблокуюче: якщо отримувач
+// не встигає і в каналі немає місця, знімок відкидається, тож повільний
+// споживач не гальмує обчислення. Канал не закривається. Кожне обчислення
+// з цими налаштуваннями (наприклад, кожен виклик Accumulator.Add) надсилає
+// знімки лише власних точок. Значення every не більше за 0 або nil-канал вимикають знімки.
+func WithSnapshots(ch chan<- PiResult, every time.Duration) Option {
+	return func(o *options) {
+		o.snapshotCh = ch
+		o.snapshotEvery = every
+	}
+}
+
+// snapshotTracker накопичує підсумки порцій поточного обчислення
+// і періодично надсилає оцінку за ними.
+type snapshotTracker struct {
+	ch       chan<- PiResult
+	every    time.Duration
+	unitSize int
+	threads  int
+
+	sum  atomicResult
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newSnapshotTracker створює трекер або повертає nil, якщо знімки вимкнено.
+func newSnapshotTracker(ch chan<- PiResult, every time.Duration, unitSize, threads int) *snapshotTracker {
+	if ch == nil || every <= 0 {
+		return nil
+	}
+	return &snapshotTracker{ch: ch, every: every, unitSize: unitSize, threads: threads}
+}
+
+// start запускає горутину, що надсилає знімки, і повертає функцію, яка
+// зупиняє її та надсилає підсумковий знімок. Безпечний для nil-трекера.
+func (t *snapshotTracker) start() func() {
+	if t == nil {
+		return func() {}
+	}
+	t.stop = make(chan struct{})
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		ticker := time.NewTicker(t.every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				t.send()
+			case <-t.stop:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(t.stop)
+		t.wg.Wait()
+		t.send()
+	}
+}
+
+// add додає підсумки порції. Безпечний для nil-трекера.
+func (t *snapshotTracker) add(w workerResult) {
+	if t == nil {
+		return
+	}
+	t.sum.add(w)
+}
+
+// send неблокуюче надсилає оцінку за накопиченими підсумками.
+func (t *snapshotTracker) send() {
+	res := t.sum.load().piResult(t.unitSize)
+	res.Threads = t.threads
+	select {
+	case t.ch <- res:
+	default:
+	}
+}
@@ -0,0 +1,53 @@
+package montecarlo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// maxSphereDimensions — найбільша підтримувана розмірність простору для EstimateSphereVolume.
+// Частка влучень V_n/2^n спадає надекспоненційно: при n = 20 вона становить
+// близько 2.5e-8, тож навіть для 10^9 точок очікується лише кілька десятків
+// влучень, а для більших n оцінка майже завжди дорівнювала б нулю.
+const maxSphereDimensions = 20
+
+// sphereKernel створює kernelFactory, що генерує точки в [0,1]^dimensions
+// і перевіряє, чи сума квадратів координат не перевищує 1.
+func sphereKernel(dimensions int) kernelFactory {
+	return func(r *rand.Rand) kernel {
+		return func(n int) workerResult {
+			inside := 0
+			for i := 0; i < n; i++ {
+				sum := 0.0
+				for d := 0; d < dimensions; d++ {
+					v := r.Float64()
+					sum += v * v
+				}
+				if sum <= 1.0 {
+					inside++
+				}
+			}
+			return pointResult(n, inside)
+		}
+	}
+}
+
+// EstimateSphereVolume оцінює об'єм одиничної кулі у просторі розмірності dimensions.
+// Точки генеруються в [0,1]^dimensions, тож частка влучень масштабується на 2^dimensions.
+// Масштаб обчислюється у float64 через math.Ldexp, тож не переповнюється і не втрачає точності.
+// Випадок dimensions=2 дає оцінку площі одиничного кола, тобто PI.
+// Повертає помилку, якщо dimensions поза межами від 1 до 20.
+func EstimateSphereVolume(dimensions, totalPoints, numThreads int, opts ...Option) (float64, error) {
+	if dimensions < 1 || dimensions > maxSphereDimensions {
+		return 0, fmt.Errorf("dimensions must be in [1, %d], got %d", maxSphereDimensions, dimensions)
+	}
+
+	total, err := run(context.Background(), sphereKernel(dimensions), totalPoints, numThreads, newOptions(opts))
+	if err != nil {
+		return 0, err
+	}
+	fraction := float64(total.inside) / float64(total.sampled)
+	return math.Ldexp(fraction, dimensions), nil
+}
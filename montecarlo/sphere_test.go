@@ -0,0 +1,37 @@
+package montecarlo
+
+import (
+	"math"
+	"testing"
+)
+
+// TestEstimateSphereVolume10 порівнює оцінку об'єму 10-вимірної одиничної кулі
+// з точним значенням π^5/120 ≈ 2.5502. Допуск — 5 стандартних похибок
+// біноміальної частки влучень, помножених на масштаб 2^10.
+func TestEstimateSphereVolume10(t *testing.T) {
+	const points = 4_000_000
+	want := math.Pow(math.Pi, 5) / 120
+	got, err := EstimateSphereVolume(10, points, 4, WithSeed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := want / 1024
+	tolerance := 5 * 1024 * math.Sqrt(p*(1-p)/points)
+	if d := math.Abs(got - want); d > tolerance {
+		t.Errorf("volume = %v, want %v ± %v", got, want, tolerance)
+	}
+}
+
+// TestEstimateSphereVolumeDimensions перевіряє межі допустимої розмірності.
+func TestEstimateSphereVolumeDimensions(t *testing.T) {
+	for _, dims := range []int{-1, 0, maxSphereDimensions + 1} {
+		if _, err := EstimateSphereVolume(dims, 1000, 2); err == nil {
+			t.Errorf("dimensions %d: expected an error", dims)
+		}
+	}
+	for _, dims := range []int{1, maxSphereDimensions} {
+		if _, err := EstimateSphereVolume(dims, 1000, 2, WithSeed(1)); err != nil {
+			t.Errorf("dimensions %d: %v", dims, err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package montecarlo
+
+import "math/rand/v2"
+
+// stratifiedKernel створює kernelFactory, що ділить одиничний квадрат на сітку
+// gridN×gridN клітинок і генерує по одній випадковій точці в кожній клітинці
+// по черзі. Повний обхід сітки з gridN² точок утворює одне випробування,
+// тож дисперсія оцінюється за обходами, а не за окремими точками.
+// Якщо n не кратне gridN², незавершений обхід продовжується в наступному виклику.
+func stratifiedKernel(region Region, gridN int, o options) kernelFactory {
+	cells := gridN * gridN
+	step := 1.0 / float64(gridN)
+	return func(r *rand.Rand) kernel {
+		coords := o.coordinates(r)
+
+		var (
+			cell int   // Індекс поточної клітинки незавершеного обходу
+			hits int64 // Влучення незавершеного обходу
+		)
+		return func(n int) workerResult {
+			res := workerResult{sampled: int64(n)}
+			for i := 0; i < n; i++ {
+				u, v := coords()
+				x := (float64(cell%gridN) + u) * step
+				y := (float64(cell/gridN) + v) * step
+				if region(x, y) {
+					res.inside++
+					hits++
+				}
+
+				cell++
+				if cell == cells {
+					res.units++
+					res.unitInside += hits
+					res.unitSq += hits * hits
+					cell, hits = 0, 0
+				}
+			}
+			return res
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package montecarlo
+
+import (
+	"math"
+	"testing"
+)
+
+// TestStratifiedReducesStdErr порівнює стандартну похибку з WithStratified і без
+// при тій самій кількості точок. Для сітки 10×10 похибка падає приблизно
+// до 0.37 від звичайної, тож поріг 0.6 залишає великий запас.
+func TestStratifiedReducesStdErr(t *testing.T) {
+	// 250000 точок на воркер кратні 100 точкам одного обходу сітки
+	const points = 1_000_000
+	for _, seed := range []int64{1, 2, 3} {
+		plain, err := EstimatePi(points, 4, WithSeed(seed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		strat, err := EstimatePi(points, 4, WithSeed(seed), WithStratified(10))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ratio := strat.StdErr / plain.StdErr; ratio > 0.6 {
+			t.Errorf("seed %d: stratified StdErr %v, plain %v (ratio %.3f)", seed, strat.StdErr, plain.StdErr, ratio)
+		}
+		if d := math.Abs(strat.Pi - math.Pi); d > 5*strat.StdErr {
+			t.Errorf("seed %d: stratified Pi = %v, off by %.1f standard errors", seed, strat.Pi, d/strat.StdErr)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package montecarlo
+
+import (
+	"context"
+	"time"
+)
+
+// throttleUpdatesPerSecond — скільки разів на секунду обмежений воркер
+// передає свої підсумки, щоб оцінка оновлювалася плавно.
+const throttleUpdatesPerSecond = 10
+
+// WithThrottle обмежує швидкість кожного воркера до pointsPerSecond точок
+// за секунду: після кожної порції воркер чекає, доки кількість оброблених ним
+// точок не відповідатиме заданій швидкості. Порції при цьому зменшуються
+// до десятої частки секундної норми, тож підсумки надходять приблизно
+// десять разів на секунду. Призначено для демонстрацій, де обчислення не має
+// завершуватися миттєво; виміряний час обчислення з обмеженням значення не має.
+// Очікування переривається скасуванням контексту. Значення не більше за 0 вимикає обмеження.
+func WithThrottle(pointsPerSecond float64) Option {
+	return func(o *options) {
+		o.throttleRate = pointsPerSecond
+	}
+}
+
+// throttle стежить за швидкістю одного воркера.
+type throttle struct {
+	rate  float64 // Точок за секунду
+	begin time.Time
+}
+
+// newThrottle створює обмежувач або повертає nil, якщо обмеження вимкнено.
+func newThrottle(rate float64) *throttle {
+	if rate <= 0 {
+		return nil
+	}
+	return &throttle{rate: rate, begin: time.Now()}
+}
+
+// batch обмежує розмір порції n десятою часткою секундної норми.
+// Безпечний для nil-обмежувача.
+func (t *throttle) batch(n int) int {
+	if t == nil {
+		return n
+	}
+	return max(1, min(n, int(t.rate/throttleUpdatesPerSecond)))
+}
+
+// wait чекає, доки done оброблених точок не відповідатиме заданій швидкості,
+// або доки ctx не буде скасовано. Безпечний для nil-обмежувача.
+func (t *throttle) wait(ctx context.Context, done int) {
+	if t == nil {
+		return
+	}
+	due := t.begin.Add(time.Duration(float64(done) / t.rate * float64(time.Second)))
+	timer := time.NewTimer(time.Until(due))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
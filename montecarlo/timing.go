@@ -0,0 +1,80 @@
+package montecarlo
+
+import (
+	"sync"
+	"time"
+)
+
+// WorkerTiming описує розподіл часу роботи воркерів одного обчислення.
+// Великий розрив між Min і Max означає нерівномірне навантаження:
+// загальний час визначає найповільніший воркер.
+type WorkerTiming struct {
+	Workers int           // Кількість воркерів, що виконали роботу
+	Min     time.Duration // Час найшвидшого воркера
+	Max     time.Duration // Час найповільнішого воркера
+	Mean    time.Duration // Середній час воркера
+}
+
+// WithWorkerTiming задає колбек, що після завершення обчислення один раз
+// отримує статистику часу роботи воркерів. Кожна горутина вимірює власний
+// час від запуску до відправлення підсумків; з WithChunkSize сюди входять
+// усі порції, оброблені горутиною, а в Pool — кожне завдання окремо.
+// Колбек викликається з горутини, що викликала функцію оцінки.
+func WithWorkerTiming(fn func(WorkerTiming)) Option {
+	return func(o *options) {
+		o.timingFn = fn
+	}
+}
+
+// timingTracker збирає час роботи воркерів одного обчислення.
+type timingTracker struct {
+	fn func(WorkerTiming)
+
+	mu     sync.Mutex
+	timing WorkerTiming
+	sum    time.Duration
+}
+
+// newTimingTracker створює трекер або повертає nil, якщо колбек не задано.
+func newTimingTracker(fn func(WorkerTiming)) *timingTracker {
+	if fn == nil {
+		return nil
+	}
+	return &timingTracker{fn: fn}
+}
+
+// start повертає функцію, що записує час від виклику start до свого виклику.
+// Безпечний для nil-трекера.
+func (t *timingTracker) start() func() {
+	if t == nil {
+		return func() {}
+	}
+	begin := time.Now()
+	return func() { t.add(time.Since(begin)) }
+}
+
+// add записує час роботи одного воркера.
+func (t *timingTracker) add(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.timing.Workers == 0 || d < t.timing.Min {
+		t.timing.Min = d
+	}
+	t.timing.Max = max(t.timing.Max, d)
+	t.sum += d
+	t.timing.Workers++
+}
+
+// report передає зібрану статистику колбеку. Безпечний для nil-трекера.
+func (t *timingTracker) report() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	timing := t.timing
+	if timing.Workers > 0 {
+		timing.Mean = t.sum / time.Duration(timing.Workers)
+	}
+	t.mu.Unlock()
+	t.fn(timing)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/njnjfnj/DSPCT-Lab2-Ilhin-KI-32/montecarlo"
+)
+
+// planRow описує розподіл точок між воркерами для однієї кількості потоків.
+type planRow struct {
+	Threads   int
+	PerWorker int   // Базова кількість точок на воркера
+	Remainder int   // Кількість точок, що залишилася після рівного поділу
+	Parts     []int // Кількість точок кожного воркера
+}
+
+// planWork обчислює розподіл totalPoints точок для кожної кількості потоків, не запускаючи обчислень.
+func planWork(totalPoints int, threadCounts []int) ([]planRow, error) {
+	rows := make([]planRow, 0, len(threadCounts))
+	for _, numThreads := range threadCounts {
+		parts, err := montecarlo.SplitPoints(totalPoints, numThreads)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, planRow{
+			Threads:   numThreads,
+			PerWorker: totalPoints / numThreads,
+			Remainder: totalPoints % numThreads,
+			Parts:     parts,
+		})
+	}
+	return rows, nil
+}
+
+// planReport формує markdown-таблицю запланованого розподілу точок.
+func planReport(totalPoints int, rows []planRow) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**План розподілу %d точок між воркерами:**\n\n", totalPoints)
+	sb.WriteString("| Кількість Потоків | Точок на воркера | Залишок | Точок у кожного воркера |\n")
+	for _, row := range rows {
+		parts := make([]string, len(row.Parts))
+		for i, p := range row.Parts {
+			parts[i] = strconv.Itoa(p)
+		}
+		fmt.Fprintf(&sb, "| %-17d | %d | %d | %s |\n", row.Threads, row.PerWorker, row.Remainder, strings.Join(parts, ", "))
+	}
+	return sb.String()
+}
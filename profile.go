@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// startCPUProfile починає запис CPU-профілю у файл path.
+// Повернена функція зупиняє профілювання і закриває файл.
+func startCPUProfile(path string) (func(), error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("не вдалося створити файл CPU-профілю: %w", err)
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("не вдалося почати CPU-профілювання: %w", err)
+	}
+	return func() {
+		pprof.StopCPUProfile()
+		f.Close()
+	}, nil
+}
+
+// writeMemProfile записує профіль пам'яті у файл path.
+func writeMemProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("не вдалося створити файл профілю пам'яті: %w", err)
+	}
+	defer f.Close()
+
+	runtime.GC() // Актуальна статистика живих об'єктів
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		return fmt.Errorf("не вдалося записати профіль пам'яті: %w", err)
+	}
+	return nil
+}
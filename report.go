@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/njnjfnj/DSPCT-Lab2-Ilhin-KI-32/montecarlo"
+)
+
+// benchmarkRow описує результат обчислення для однієї кількості потоків.
+type benchmarkRow struct {
+	Threads    int       `json:"threads"`
+	Points     int       `json:"-"`
+	Pi         float64   `json:"pi"`
+	StdErr     float64   `json:"-"`
+	AbsError   float64   `json:"abs_error"`      // |PI - math.Pi|
+	RelError   float64   `json:"rel_error_pct"`  // Відносна похибка у відсотках
+	ElapsedMs  float64   `json:"elapsed_ms"`     // Середній час обчислення
+	ElapsedStd float64   `json:"elapsed_std_ms"` // Стандартне відхилення часу
+	Runs       int       `json:"runs"`           // Кількість повторів
+	Speedup    float64   `json:"speedup"`        // Прискорення відносно послідовного обчислення
+	Efficiency float64   `json:"efficiency"`     // Прискорення, поділене на кількість потоків
+	Throughput float64   `json:"throughput"`     // Точок за секунду при середньому часі
+	Sequential bool      `json:"-"`              // Рядок послідовного обчислення
+	Timestamp  string    `json:"timestamp"`      // Час завершення обчислень конфігурації, RFC3339
+	Mem        *memUsage `json:"mem,omitempty"`  // Використання пам'яті; лише з -memstats
+
+	elapsed time.Duration
+}
+
+// newBenchmarkRow створює рядок звіту з результатів повторних обчислень однієї конфігурації
+// з позначкою поточного часу.
+// Середнє PI та середній час рахуються незалежно; для часу також
+// обчислюється вибіркове стандартне відхилення.
+func newBenchmarkRow(threads, points int, runs []montecarlo.PiResult) benchmarkRow {
+	n := float64(len(runs))
+
+	var sumPi, sumStdErr float64
+	for _, res := range runs {
+		sumPi += res.Pi
+		sumStdErr += res.StdErr
+	}
+
+	var sumElapsed time.Duration
+	for _, res := range runs {
+		sumElapsed += res.Elapsed
+	}
+	meanElapsed := sumElapsed / time.Duration(len(runs))
+
+	var sqDiff float64
+	for _, res := range runs {
+		d := durationMs(res.Elapsed) - durationMs(meanElapsed)
+		sqDiff += d * d
+	}
+	elapsedStd := 0.0
+	if len(runs) > 1 {
+		elapsedStd = math.Sqrt(sqDiff / (n - 1))
+	}
+
+	meanPi := sumPi / n
+	absError := math.Abs(meanPi - math.Pi)
+
+	return benchmarkRow{
+		Threads:    threads,
+		Points:     points,
+		Pi:         meanPi,
+		StdErr:     sumStdErr / n,
+		AbsError:   absError,
+		RelError:   absError / math.Pi * 100,
+		ElapsedMs:  durationMs(meanElapsed),
+		ElapsedStd: elapsedStd,
+		Runs:       len(runs),
+		Throughput: throughput(points, meanElapsed),
+		Timestamp:  time.Now().Format(time.RFC3339),
+		elapsed:    meanElapsed,
+	}
+}
+
+// throughput повертає кількість точок за секунду, або 0 для нульового часу.
+func throughput(points int, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(points) / elapsed.Seconds()
+}
+
+// formatElapsed форматує середній час, додаючи відхилення при кількох повторах.
+func (row benchmarkRow) formatElapsed() string {
+	if row.Runs > 1 {
+		return fmt.Sprintf("%.2f ± %.2f", row.ElapsedMs, row.ElapsedStd)
+	}
+	return fmt.Sprintf("%.2f", row.ElapsedMs)
+}
+
+// computeSpeedup заповнює прискорення та ефективність кожного рядка
+// відносно часу baseline. Якщо час рядка нульовий, обидва значення
+// залишаються нульовими, щоб уникнути ділення на нуль.
+func computeSpeedup(rows []benchmarkRow, baseline time.Duration) {
+	for i := range rows {
+		if rows[i].elapsed <= 0 {
+			continue
+		}
+		rows[i].Speedup = float64(baseline) / float64(rows[i].elapsed)
+		rows[i].Efficiency = rows[i].Speedup / float64(rows[i].Threads)
+	}
+}
+
+// formatRatio форматує прискорення чи ефективність, позначаючи відсутні значення прочерком.
+func formatRatio(v float64) string {
+	if v == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+// durationMs переводить тривалість у мілісекунди.
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000.0
+}
+
+// markdownReport формує звіт у вигляді markdown-таблиці з відомостями про систему.
+// Якщо рядки містять статистику пам'яті, до таблиці додаються відповідні стовпці.
+func markdownReport(info systemInfo, rows []benchmarkRow) string {
+	var sb strings.Builder
+	sb.WriteString("**Звіт про залежність часу обчислення від кількості потоків:**\n\n")
+	fmt.Fprintf(&sb, "Система: %s\n\n", info)
+	withMem := len(rows) > 0 && rows[0].Mem != nil
+	sb.WriteString("| Кількість Потоків | Отримане PI | Абсолютна похибка | Відносна похибка (%) | Час Обчислення (мс) | Прискорення | Ефективність | Точок/с |")
+	if withMem {
+		sb.WriteString(" Виділено пам'яті | Збирань сміття |")
+	}
+	sb.WriteString("\n")
+
+	for _, row := range rows {
+		threads := fmt.Sprint(row.Threads)
+		if row.Sequential {
+			threads += " (Послідовно)"
+		}
+		fmt.Fprintf(&sb, "| %-17s | %s | %.6f | %.4f | %s | %s | %s | %.3g |",
+			threads, formatEstimate(row.Pi, row.StdErr), row.AbsError, row.RelError,
+			row.formatElapsed(), formatRatio(row.Speedup), formatRatio(row.Efficiency), row.Throughput)
+		if withMem && row.Mem != nil {
+			fmt.Fprintf(&sb, " %s | %d |", formatBytes(row.Mem.AllocBytes), row.Mem.NumGC)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// jsonRow — елемент JSON-звіту: рядок результатів разом з відомостями про систему.
+// Звіт залишається масивом об'єктів, як і до появи відомостей про систему,
+// тож вони додаються до кожного елемента, а не обгортають масив.
+type jsonRow struct {
+	benchmarkRow
+	System systemInfo `json:"system"`
+}
+
+// writeJSONReport записує рядки звіту як JSON-масив.
+func writeJSONReport(w io.Writer, info systemInfo, rows []benchmarkRow) error {
+	records := make([]jsonRow, len(rows))
+	for i, row := range rows {
+		records[i] = jsonRow{benchmarkRow: row, System: info}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
+
+// ndjsonRecord — рядок NDJSON-виводу для однієї конфігурації.
+type ndjsonRecord struct {
+	Threads    int     `json:"threads"`
+	Points     int     `json:"points"`
+	Pi         float64 `json:"pi"`
+	ElapsedMs  float64 `json:"elapsed_ms"`
+	Error      float64 `json:"error"` // Стандартна похибка оцінки PI
+	Sequential bool    `json:"sequential,omitempty"`
+	Timestamp  string  `json:"timestamp"`
+	Commit     string  `json:"commit"`
+}
+
+// writeNDJSONRow записує row одним рядком JSON.
+func writeNDJSONRow(w io.Writer, row benchmarkRow) error {
+	return json.NewEncoder(w).Encode(ndjsonRecord{
+		Threads:    row.Threads,
+		Points:     row.Points,
+		Pi:         row.Pi,
+		ElapsedMs:  row.ElapsedMs,
+		Error:      row.StdErr,
+		Sequential: row.Sequential,
+		Timestamp:  row.Timestamp,
+		Commit:     Commit,
+	})
+}
+
+// csvHeader — заголовок CSV-файлу з результатами.
+var csvHeader = []string{"threads", "points", "pi", "elapsed_ms", "error", "timestamp", "commit"}
+
+// openCSV відкриває CSV-файл для дописування результатів.
+// Якщо файл новий (порожній), одразу записується заголовок. Якщо файл уже
+// має інший заголовок, наприклад старішої версії з меншою кількістю стовпців,
+// повертається помилка, щоб не змішувати рядки різного формату.
+func openCSV(path string) (*os.File, *csv.Writer, error) {
+	if err := checkCSVHeader(path); err != nil {
+		return nil, nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("не вдалося відкрити CSV-файл: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("не вдалося перевірити CSV-файл: %w", err)
+	}
+
+	w := csv.NewWriter(f)
+	if info.Size() == 0 {
+		if err := w.Write(csvHeader); err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+	}
+	return f, w, nil
+}
+
+// checkCSVHeader перевіряє, що наявний непорожній файл path починається з csvHeader.
+// Відсутній чи порожній файл вважається коректним.
+func checkCSVHeader(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("не вдалося відкрити CSV-файл: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("не вдалося прочитати заголовок CSV-файлу: %w", err)
+	}
+	if !slices.Equal(header, csvHeader) {
+		return fmt.Errorf("CSV-файл %s має заголовок %q, а не %q; вкажіть новий файл",
+			path, strings.Join(header, ","), strings.Join(csvHeader, ","))
+	}
+	return nil
+}
+
+// writeCSVRows дописує рядки результатів у CSV.
+// Стовпець error містить стандартну похибку оцінки PI, а timestamp і commit —
+// час отримання рядка та версію коду.
+func writeCSVRows(w *csv.Writer, rows []benchmarkRow) error {
+	for _, row := range rows {
+		record := []string{
+			strconv.Itoa(row.Threads),
+			strconv.Itoa(row.Points),
+			strconv.FormatFloat(row.Pi, 'f', 6, 64),
+			strconv.FormatFloat(row.ElapsedMs, 'f', 3, 64),
+			strconv.FormatFloat(row.StdErr, 'g', 6, 64),
+			row.Timestamp,
+			Commit,
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
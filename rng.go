@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	randv1 "math/rand"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/njnjfnj/DSPCT-Lab2-Ilhin-KI-32/montecarlo"
+)
+
+// rngCandidate — генератор випадкових чисел для порівняння.
+// Factory передається у montecarlo.WithRandFactory.
+type rngCandidate struct {
+	Name    string
+	Factory func(workerIndex int) *rand.Rand
+}
+
+// defaultRNGCandidates повертає генератори для порівняння: PCG з math/rand/v2
+// (генератор бібліотеки за замовчуванням), ChaCha8 з math/rand/v2 та застарілий
+// math/rand, зерна яких виводяться з seed та індексу воркера. PCG створюється
+// так само, як у бібліотеці з WithSeed(seed), тож усі рядки залежать від seed однаково.
+// Щоб додати власний генератор, достатньо дописати його до списку.
+func defaultRNGCandidates(seed int64) []rngCandidate {
+	return []rngCandidate{
+		{Name: "math/rand/v2 PCG", Factory: func(workerIndex int) *rand.Rand {
+			return rand.New(rand.NewPCG(uint64(seed), uint64(workerIndex)))
+		}},
+		{Name: "math/rand/v2 ChaCha8", Factory: func(workerIndex int) *rand.Rand {
+			var key [32]byte
+			binary.LittleEndian.PutUint64(key[0:], uint64(seed))
+			binary.LittleEndian.PutUint64(key[8:], uint64(workerIndex))
+			return rand.New(rand.NewChaCha8(key))
+		}},
+		{Name: "math/rand", Factory: func(workerIndex int) *rand.Rand {
+			// *randv1.Rand має метод Uint64, тож сам є джерелом для math/rand/v2
+			return rand.New(randv1.New(randv1.NewSource(seed + int64(workerIndex))))
+		}},
+	}
+}
+
+// rngRow описує результат обчислення з одним генератором.
+type rngRow struct {
+	Name       string
+	Pi         float64
+	StdErr     float64
+	Elapsed    time.Duration
+	Throughput float64
+}
+
+// runRNGComparison обчислює PI паралельно з кожним генератором із candidates.
+func runRNGComparison(totalPoints, numThreads int, candidates []rngCandidate) ([]rngRow, error) {
+	rows := make([]rngRow, 0, len(candidates))
+	for _, c := range candidates {
+		res, err := montecarlo.Timed(func() (montecarlo.PiResult, error) {
+			return montecarlo.EstimatePi(totalPoints, numThreads, montecarlo.WithRandFactory(c.Factory))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Name, err)
+		}
+		rows = append(rows, rngRow{
+			Name:       c.Name,
+			Pi:         res.Pi,
+			StdErr:     res.StdErr,
+			Elapsed:    res.Elapsed,
+			Throughput: res.Throughput,
+		})
+	}
+	return rows, nil
+}
+
+// rngReport формує markdown-таблицю порівняння генераторів.
+func rngReport(rows []rngRow) string {
+	var sb strings.Builder
+	sb.WriteString("**Порівняння генераторів випадкових чисел:**\n\n")
+	sb.WriteString("| Генератор | Отримане PI | Час Обчислення (мс) | Точок/с |\n")
+	for _, row := range rows {
+		fmt.Fprintf(&sb, "| %-20s | %.6f ± %.4f | %.2f | %.3g |\n",
+			row.Name, row.Pi, row.StdErr, durationMs(row.Elapsed), row.Throughput)
+	}
+	return sb.String()
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/njnjfnj/DSPCT-Lab2-Ilhin-KI-32/montecarlo"
+)
+
+// seedEnvVar — змінна середовища з базовим зерном генератора.
+const seedEnvVar = "MONTECARLO_SEED"
+
+// resolveSeed визначає базове зерно генератора. Пріоритет: прапорець -seed,
+// потім змінна середовища MONTECARLO_SEED. Якщо жодне не задано, повертає
+// ok == false, і зерно береться з поточного часу. Нечислове значення змінної
+// середовища ігнорується з попередженням.
+func resolveSeed(flagSeed int64, flagSet bool, logger *slog.Logger) (seed int64, ok bool) {
+	if flagSet {
+		return flagSeed, true
+	}
+	value, found := os.LookupEnv(seedEnvVar)
+	if !found {
+		return 0, false
+	}
+	seed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		logger.Warn("некоректне зерно у змінній середовища, використовується поточний час",
+			"var", seedEnvVar, "value", value)
+		return 0, false
+	}
+	return seed, true
+}
+
+// seedOptions визначає зерно через resolveSeed за прапорцем -seed набору fs
+// і повертає відповідні опції обчислення. Якщо зерно не задано, опції порожні,
+// а повернене зерно береться з поточного часу для режимів, яким воно потрібне явно.
+func seedOptions(fs *flag.FlagSet, flagSeed int64, logger *slog.Logger) (opts []montecarlo.Option, seed int64, ok bool) {
+	flagSet := false
+	fs.Visit(func(f *flag.Flag) { flagSet = flagSet || f.Name == "seed" })
+	seed, ok = resolveSeed(flagSeed, flagSet, logger)
+	if !ok {
+		return nil, time.Now().UnixNano(), false
+	}
+	logger.Info("фіксоване зерно генератора", "seed", seed)
+	return []montecarlo.Option{montecarlo.WithSeed(seed)}, seed, true
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/njnjfnj/DSPCT-Lab2-Ilhin-KI-32/montecarlo"
+)
+
+// seedSpread описує розкид оцінок PI між запусками з різними фіксованими зернами.
+type seedSpread struct {
+	Seeds      []int64   // Використані зерна
+	Estimates  []float64 // Оцінка PI для кожного зерна
+	Min, Max   float64
+	Mean       float64
+	StdDev     float64 // Вибіркове стандартне відхилення оцінок
+	MeanStdErr float64 // Середня стандартна похибка, передбачена кожним запуском
+}
+
+// runSeedSpread обчислює PI паралельно з numSeeds зернами firstSeed, firstSeed+1, ...
+// при однаковій кількості точок.
+func runSeedSpread(totalPoints, numThreads, numSeeds int, firstSeed int64) (seedSpread, error) {
+	spread := seedSpread{Min: math.Inf(1), Max: math.Inf(-1)}
+	var sum, sumStdErr float64
+	for i := 0; i < numSeeds; i++ {
+		seed := firstSeed + int64(i)
+		res, err := montecarlo.EstimatePi(totalPoints, numThreads, montecarlo.WithSeed(seed))
+		if err != nil {
+			return seedSpread{}, err
+		}
+		spread.Seeds = append(spread.Seeds, seed)
+		spread.Estimates = append(spread.Estimates, res.Pi)
+		spread.Min = min(spread.Min, res.Pi)
+		spread.Max = max(spread.Max, res.Pi)
+		sum += res.Pi
+		sumStdErr += res.StdErr
+	}
+
+	n := float64(numSeeds)
+	spread.Mean = sum / n
+	spread.MeanStdErr = sumStdErr / n
+	if numSeeds > 1 {
+		var sqDiff float64
+		for _, pi := range spread.Estimates {
+			d := pi - spread.Mean
+			sqDiff += d * d
+		}
+		spread.StdDev = math.Sqrt(sqDiff / (n - 1))
+	}
+	return spread, nil
+}
+
+// seedSpreadReport формує markdown-звіт розкиду оцінок між зернами.
+func seedSpreadReport(totalPoints int, spread seedSpread) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**Розкид оцінки PI для %d зерен при %d точках:**\n\n", len(spread.Seeds), totalPoints)
+	sb.WriteString("| Зерно | Отримане PI |\n")
+	for i, seed := range spread.Seeds {
+		fmt.Fprintf(&sb, "| %-5d | %.6f |\n", seed, spread.Estimates[i])
+	}
+	fmt.Fprintf(&sb, "\nМінімум: %.6f, максимум: %.6f, середнє: %.6f\n", spread.Min, spread.Max, spread.Mean)
+	fmt.Fprintf(&sb, "Стандартне відхилення оцінок: %.6f (передбачена похибка одного запуску: %.6f)\n",
+		spread.StdDev, spread.MeanStdErr)
+	return sb.String()
+}
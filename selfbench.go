@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/njnjfnj/DSPCT-Lab2-Ilhin-KI-32/montecarlo"
+)
+
+// Фіксовані параметри самотестування продуктивності, однакові на всіх машинах.
+const (
+	selfbenchSeed    = 1
+	selfbenchPoints  = 10000000
+	selfbenchRepeats = 3 // Береться найкращий час із повторів, щоб зменшити вплив шуму
+)
+
+// selfbenchResult — підсумок самотестування продуктивності.
+type selfbenchResult struct {
+	NumCPU     int
+	Sequential time.Duration // Найкращий час послідовного обчислення
+	Threads    int           // Кількість потоків з найбільшим прискоренням
+	Speedup    float64
+	Efficiency float64
+}
+
+// bestTime повертає найменший час виконання f із selfbenchRepeats повторів.
+func bestTime(f func() (montecarlo.PiResult, error)) (time.Duration, error) {
+	var best time.Duration
+	for i := 0; i < selfbenchRepeats; i++ {
+		res, err := montecarlo.Timed(f)
+		if err != nil {
+			return 0, err
+		}
+		if i == 0 || res.Elapsed < best {
+			best = res.Elapsed
+		}
+	}
+	return best, nil
+}
+
+// runSelfbench вимірює прискорення паралельного обчислення з фіксованим зерном і кількістю точок
+// для кожної кількості потоків від 1 до runtime.NumCPU() і повертає найкращий результат.
+func runSelfbench() (selfbenchResult, error) {
+	res := selfbenchResult{NumCPU: runtime.NumCPU()}
+	seq, err := bestTime(func() (montecarlo.PiResult, error) {
+		return montecarlo.EstimatePiSequential(selfbenchPoints, montecarlo.WithSeed(selfbenchSeed)), nil
+	})
+	if err != nil {
+		return res, err
+	}
+	res.Sequential = seq
+
+	for numThreads := 1; numThreads <= res.NumCPU; numThreads++ {
+		elapsed, err := bestTime(func() (montecarlo.PiResult, error) {
+			return montecarlo.EstimatePi(selfbenchPoints, numThreads, montecarlo.WithSeed(selfbenchSeed))
+		})
+		if err != nil {
+			return res, err
+		}
+		if elapsed <= 0 {
+			continue
+		}
+		if speedup := float64(seq) / float64(elapsed); speedup > res.Speedup {
+			res.Threads = numThreads
+			res.Speedup = speedup
+			res.Efficiency = speedup / float64(numThreads)
+		}
+	}
+	return res, nil
+}
+
+// String форматує підсумок самотестування одним рядком.
+func (r selfbenchResult) String() string {
+	return fmt.Sprintf("CPU: %d, послідовно: %.2f мс, найкраще прискорення: %.2f при %d потоках, ефективність: %.2f",
+		r.NumCPU, durationMs(r.Sequential), r.Speedup, r.Threads, r.Efficiency)
+}
+
+// printSelfbench виконує runSelfbench і виводить підсумок одним рядком.
+func printSelfbench() {
+	res, err := runSelfbench()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(res)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/njnjfnj/DSPCT-Lab2-Ilhin-KI-32/montecarlo"
+)
+
+// maxAddPoints обмежує кількість точок за один запит /add,
+// щоб один запит не займав сервер надовго.
+const maxAddPoints = 1000000000
+
+// shutdownTimeout обмежує очікування на завершення запитів при зупинці сервера.
+const shutdownTimeout = 5 * time.Second
+
+// piResponse — відповідь ендпоінтів /pi та /add.
+type piResponse struct {
+	Pi     float64 `json:"pi"`
+	StdErr float64 `json:"stderr"`
+	Total  int64   `json:"total"`
+}
+
+// newServer створює HTTP-сервер, що обслуговує накопичувач acc:
+// /pi повертає поточну оцінку, /add?points=N генерує ще N точок,
+// /metrics віддає метрики у форматі Prometheus.
+func newServer(addr string, acc *montecarlo.Accumulator) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi", func(w http.ResponseWriter, r *http.Request) {
+		writeEstimate(w, acc)
+	})
+	mux.HandleFunc("/add", func(w http.ResponseWriter, r *http.Request) {
+		points, err := strconv.Atoi(r.URL.Query().Get("points"))
+		if err != nil || points < 1 || points > maxAddPoints {
+			http.Error(w, fmt.Sprintf("points must be an integer in [1, %d]", maxAddPoints), http.StatusBadRequest)
+			return
+		}
+		if err := acc.Add(points); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, montecarlo.ErrStopped) {
+				status = http.StatusServiceUnavailable
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		writeEstimate(w, acc)
+	})
+
+	mux.Handle("/metrics", metricsHandler(acc))
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// serve обслуговує запити srv, доки не буде скасовано ctx. Після скасування
+// спершу зупиняє acc, щоб довгі запити /add завершилися, а потім
+// чекає на завершення решти запитів не довше за shutdownTimeout.
+func serve(ctx context.Context, srv *http.Server, acc *montecarlo.Accumulator) error {
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
+
+	select {
+	case err := <-errc:
+		acc.Stop()
+		return err
+	case <-ctx.Done():
+	}
+
+	acc.Stop()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// writeEstimate записує поточну оцінку накопичувача як JSON.
+func writeEstimate(w http.ResponseWriter, acc *montecarlo.Accumulator) {
+	res := acc.Result()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(piResponse{Pi: res.Pi, StdErr: res.StdErr, Total: res.Total})
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// Розміри SVG-діаграми в пікселях.
+const (
+	svgWidth   = 640
+	svgHeight  = 400
+	svgMargin  = 50 // Відступ до осей зліва і знизу
+	svgPadding = 20 // Відступ зверху і справа
+)
+
+// speedupSVG формує стовпчикову діаграму прискорення за кількістю потоків.
+// Рядок послідовного обчислення не показується: його прискорення за означенням 1,
+// і воно позначене пунктирною лінією.
+func speedupSVG(rows []benchmarkRow) string {
+	var bars []benchmarkRow
+	maxSpeedup := 1.0
+	for _, row := range rows {
+		if row.Sequential {
+			continue
+		}
+		bars = append(bars, row)
+		maxSpeedup = max(maxSpeedup, row.Speedup)
+	}
+	// Верх осі — найближче ціле над найбільшим прискоренням
+	top := math.Ceil(maxSpeedup)
+
+	plotW := float64(svgWidth - svgMargin - svgPadding)
+	plotH := float64(svgHeight - svgMargin - svgPadding)
+	originX := float64(svgMargin)
+	originY := float64(svgHeight - svgMargin)
+	y := func(v float64) float64 { return originY - v/top*plotH }
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" font-family=\"sans-serif\" font-size=\"12\">\n", svgWidth, svgHeight)
+
+	// Осі та поділки осі прискорення
+	fmt.Fprintf(&sb, "<line x1=\"%g\" y1=\"%g\" x2=\"%g\" y2=\"%g\" stroke=\"black\"/>\n", originX, originY, originX+plotW, originY)
+	fmt.Fprintf(&sb, "<line x1=\"%g\" y1=\"%g\" x2=\"%g\" y2=\"%g\" stroke=\"black\"/>\n", originX, originY, originX, originY-plotH)
+	step := math.Max(1, math.Ceil(top/10))
+	for v := 0.0; v <= top; v += step {
+		fmt.Fprintf(&sb, "<text x=\"%g\" y=\"%g\" text-anchor=\"end\">%g</text>\n", originX-5, y(v)+4, v)
+	}
+	fmt.Fprintf(&sb, "<line x1=\"%g\" y1=\"%g\" x2=\"%g\" y2=\"%g\" stroke=\"gray\" stroke-dasharray=\"4\"/>\n", originX, y(1), originX+plotW, y(1))
+
+	// Стовпчики з підписами кількості потоків і значень
+	if len(bars) > 0 {
+		slot := plotW / float64(len(bars))
+		barW := slot * 0.7
+		for i, row := range bars {
+			x := originX + float64(i)*slot + (slot-barW)/2
+			fmt.Fprintf(&sb, "<rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" fill=\"steelblue\"/>\n", x, y(row.Speedup), barW, originY-y(row.Speedup))
+			fmt.Fprintf(&sb, "<text x=\"%.1f\" y=\"%.1f\" text-anchor=\"middle\">%d</text>\n", x+barW/2, originY+15, row.Threads)
+			fmt.Fprintf(&sb, "<text x=\"%.1f\" y=\"%.1f\" text-anchor=\"middle\">%.2f</text>\n", x+barW/2, y(row.Speedup)-4, row.Speedup)
+		}
+	}
+
+	fmt.Fprintf(&sb, "<text x=\"%g\" y=\"%d\" text-anchor=\"middle\">Кількість потоків</text>\n", originX+plotW/2, svgHeight-10)
+	fmt.Fprintf(&sb, "<text x=\"15\" y=\"%g\" text-anchor=\"middle\" transform=\"rotate(-90 15 %g)\">Прискорення</text>\n", originY-plotH/2, originY-plotH/2)
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// writeSVG записує діаграму прискорення у файл path.
+func writeSVG(path string, rows []benchmarkRow) error {
+	if err := os.WriteFile(path, []byte(speedupSVG(rows)), 0o644); err != nil {
+		return fmt.Errorf("не вдалося записати SVG-діаграму: %w", err)
+	}
+	return nil
+}
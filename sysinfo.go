@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Commit — версія коду, з якої зібрано програму. Задається при збиранні:
+//
+//	go build -ldflags "-X main.Commit=$(git rev-parse --short HEAD)"
+var Commit = "dev"
+
+// systemInfo описує машину та середовище виконання, на яких отримано результати.
+type systemInfo struct {
+	NumCPU     int    `json:"num_cpu"`
+	GOMAXPROCS int    `json:"gomaxprocs"`
+	GoVersion  string `json:"go_version"`
+	GOOS       string `json:"goos"`
+	GOARCH     string `json:"goarch"`
+	Commit     string `json:"commit"`
+}
+
+// currentSystemInfo повертає відомості про поточну машину.
+func currentSystemInfo() systemInfo {
+	return systemInfo{
+		NumCPU:     runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		GoVersion:  runtime.Version(),
+		GOOS:       runtime.GOOS,
+		GOARCH:     runtime.GOARCH,
+		Commit:     Commit,
+	}
+}
+
+// String форматує відомості одним рядком для markdown-звіту.
+func (s systemInfo) String() string {
+	return fmt.Sprintf("CPU: %d, GOMAXPROCS: %d, %s, %s/%s, commit %s", s.NumCPU, s.GOMAXPROCS, s.GoVersion, s.GOOS, s.GOARCH, s.Commit)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// reportFormats — формати звіту, які підтримує -format.
+var reportFormats = []string{"markdown", "json", "html", "ndjson"}
+
+// runConfig — значення прапорців після застосування файлу налаштувань,
+// які перевіряються до запуску будь-якого режиму.
+type runConfig struct {
+	Points        int
+	Repeat        int
+	Format        string
+	ServeAddr     string
+	RateTarget    float64
+	HistogramPath string
+	HistogramSize int
+	CompareSeeds  int
+	EstimateCost  float64
+
+	Duration       time.Duration // -duration; 0 — режим вимкнено
+	Precision      int           // -precision: 32 або 64
+	ProfileScaling bool          // -profile-scaling
+	Threads        []int         // Кількості потоків перебору
+	Auto           bool          // Кількості потоків підбираються автоматично
+}
+
+// Validate перевіряє всі параметри одразу і повертає об'єднану через errors.Join
+// помилку з кожною знайденою проблемою, або nil, якщо конфігурація коректна.
+// Це єдине місце, де визначено, які поєднання прапорців допустимі.
+func (c runConfig) Validate() error {
+	var errs []error
+	if c.Points < 1 {
+		errs = append(errs, fmt.Errorf("кількість точок має бути додатною, отримано %d", c.Points))
+	}
+	if c.Repeat < 1 {
+		errs = append(errs, fmt.Errorf("кількість повторів має бути додатною, отримано %d", c.Repeat))
+	}
+	if !containsString(reportFormats, c.Format) {
+		errs = append(errs, fmt.Errorf("невідомий формат %q", c.Format))
+	}
+	if c.RateTarget < 0 {
+		errs = append(errs, fmt.Errorf("цільова швидкість має бути додатною, отримано %g", c.RateTarget))
+	}
+	// Обмежена швидкість робить вимірювання часу беззмістовними,
+	// тож вона дозволена лише для живої оцінки через HTTP-сервер
+	if c.RateTarget > 0 && c.ServeAddr == "" {
+		errs = append(errs, errors.New("-points-per-second-target підтримується лише з -serve, бо робить результати вимірювань часу беззмістовними"))
+	}
+	if c.HistogramPath != "" && c.HistogramSize < 1 {
+		errs = append(errs, fmt.Errorf("розмір гістограми має бути додатним, отримано %d", c.HistogramSize))
+	}
+	if c.CompareSeeds < 0 {
+		errs = append(errs, fmt.Errorf("кількість зерен має бути додатною, отримано %d", c.CompareSeeds))
+	}
+	if c.Precision != 32 && c.Precision != 64 {
+		errs = append(errs, fmt.Errorf("розрядність має бути 32 або 64, отримано %d", c.Precision))
+	}
+	if c.Duration < 0 {
+		errs = append(errs, fmt.Errorf("тривалість має бути додатною, отримано %s", c.Duration))
+	}
+	if c.EstimateCost < 0 {
+		errs = append(errs, fmt.Errorf("цільова похибка має бути додатною, отримано %g", c.EstimateCost))
+	}
+	// З -auto кількості потоків відомі лише після підбору, тож перевіряється fitAmdahl
+	if c.ProfileScaling && !c.Auto && countParallel(c.Threads) < 2 {
+		errs = append(errs, errors.New("-profile-scaling потребує щонайменше двох різних кількостей потоків більше 1 у -threads"))
+	}
+	return errors.Join(errs...)
+}
+
+// countParallel повертає кількість різних значень threads, більших за 1.
+func countParallel(threads []int) int {
+	seen := make(map[int]bool)
+	for _, n := range threads {
+		if n > 1 {
+			seen[n] = true
+		}
+	}
+	return len(seen)
+}
+
+// containsString повідомляє, чи є s серед values.
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// printErrors виводить кожну помилку з об'єднаної через errors.Join окремим рядком.
+func printErrors(w io.Writer, err error) {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		fmt.Fprintf(w, "Помилка: %v\n", err)
+		return
+	}
+	for _, e := range joined.Unwrap() {
+		fmt.Fprintf(w, "Помилка: %v\n", e)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/njnjfnj/DSPCT-Lab2-Ilhin-KI-32/montecarlo"
+)
+
+// verifySeed — фіксоване зерно для самоперевірки.
+const verifySeed = 42
+
+// runVerify обчислює PI послідовно та паралельно з одним воркером з тим самим зерном.
+// Один воркер генерує ту саму послідовність точок, що й послідовне обчислення,
+// тож будь-яка розбіжність у лічильниках вказує на помилку паралельної схеми.
+func runVerify(totalPoints int) (seq, par montecarlo.PiResult, err error) {
+	seq = montecarlo.EstimatePiSequential(totalPoints, montecarlo.WithSeed(verifySeed))
+	par, err = montecarlo.EstimatePi(totalPoints, 1, montecarlo.WithSeed(verifySeed))
+	return seq, par, err
+}
+
+// printVerify виконує runVerify для totalPoints точок і виводить підсумок.
+// Якщо перевірка не пройдена, завершує процес з кодом 1.
+func printVerify(totalPoints int) {
+	seq, par, err := runVerify(totalPoints)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Помилка: %v\n", err)
+		os.Exit(1)
+	}
+	if !isFinite(seq.Pi) || !isFinite(par.Pi) {
+		fmt.Fprintf(os.Stderr, "Перевірка не пройдена: %s (послідовно %v, паралельно %v)\n", nonFiniteMsg, seq.Pi, par.Pi)
+		os.Exit(1)
+	}
+	if seq.Inside != par.Inside || seq.Total != par.Total {
+		fmt.Fprintf(os.Stderr, "Перевірка не пройдена: послідовно %d/%d (PI %.6f), паралельно %d/%d (PI %.6f)\n",
+			seq.Inside, seq.Total, seq.Pi, par.Inside, par.Total, par.Pi)
+		os.Exit(1)
+	}
+	fmt.Printf("Перевірка пройдена: %d/%d точок у колі, PI %.6f\n", seq.Inside, seq.Total, seq.Pi)
+}